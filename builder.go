@@ -0,0 +1,381 @@
+package dactyloscopy
+
+import (
+	"crypto/ecdh"
+	"crypto/mlkem"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"slices"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// ClientHelloBuilder synthesises a ClientHello record from a Fingerprint, with
+// the same ciphersuites, extension order, groups, signature algorithms,
+// versions, ALPN, GREASE placement and key shares.  Anything which isn't
+// captured in a Fingerprint (random, session ID, key material, PSK identities
+// and so on) is generated freshly.
+type ClientHelloBuilder struct {
+	Fingerprint *Fingerprint
+	// Rand is the source of randomness for the random, session ID and key
+	// material.  Defaults to crypto/rand
+	Rand io.Reader
+	// ServerName overrides the SNI of the fingerprint, when non-empty
+	ServerName string
+}
+
+// MarshalClientHello returns a ClientHello record which will parse back to an
+// equivalent fingerprint
+func (f *Fingerprint) MarshalClientHello() ([]byte, error) {
+	return (&ClientHelloBuilder{Fingerprint: f}).Build()
+}
+
+// greaseSuite is placed at the front of the ciphersuites when the fingerprint
+// had GREASE, which is where BoringSSL and Apple put it.  Which GREASE value is
+// used doesn't matter as we discard them when parsing
+const greaseSuite uint16 = 0x0a0a
+
+// Build synthesises the ClientHello record
+func (b *ClientHelloBuilder) Build() ([]byte, error) {
+	f := b.Fingerprint
+	if f == nil {
+		return nil, fmt.Errorf("no fingerprint to build from")
+	}
+	rnd := b.Rand
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+
+	recordVersion := f.RecordTLSVersion
+	if recordVersion == 0 {
+		recordVersion = VersionTLS10
+	}
+	helloVersion := f.TLSVersion
+	if helloVersion == 0 {
+		helloVersion = VersionTLS12
+	}
+	compression := f.Compression
+	if len(compression) == 0 {
+		compression = []uint8{0}
+	}
+
+	random := make([]byte, 32)
+	if _, err := io.ReadFull(rnd, random); err != nil {
+		return nil, fmt.Errorf("generating random: %w", err)
+	}
+	var sessionID []byte
+	if f.SessionID {
+		sessionID = make([]byte, 32)
+		if _, err := io.ReadFull(rnd, sessionID); err != nil {
+			return nil, fmt.Errorf("generating session id: %w", err)
+		}
+	}
+
+	extensions, err := b.extensions(rnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var hello cryptobyte.Builder
+	hello.AddUint8(HandshakeType)
+	hello.AddUint16(recordVersion)
+	hello.AddUint16LengthPrefixed(func(record *cryptobyte.Builder) {
+		record.AddUint8(ClientHelloMsg)
+		record.AddUint24LengthPrefixed(func(handshake *cryptobyte.Builder) {
+			handshake.AddUint16(helloVersion)
+			handshake.AddBytes(random)
+			handshake.AddUint8LengthPrefixed(func(s *cryptobyte.Builder) {
+				s.AddBytes(sessionID)
+			})
+			handshake.AddUint16LengthPrefixed(func(suites *cryptobyte.Builder) {
+				if f.Grease {
+					suites.AddUint16(greaseSuite)
+				}
+				for _, suite := range f.Ciphersuite {
+					suites.AddUint16(suite)
+				}
+			})
+			handshake.AddUint8LengthPrefixed(func(c *cryptobyte.Builder) {
+				c.AddBytes(compression)
+			})
+			if len(extensions) > 0 {
+				handshake.AddUint16LengthPrefixed(func(exts *cryptobyte.Builder) {
+					exts.AddBytes(extensions)
+				})
+			}
+		})
+	})
+	return hello.Bytes()
+}
+
+// extensions builds the extensions block in the order of the fingerprint
+func (b *ClientHelloBuilder) extensions(rnd io.Reader) ([]byte, error) {
+	f := b.Fingerprint
+	order := slices.Clone(f.Extensions)
+
+	// Padding isn't recorded in the extension list, so we put it where
+	// BoringSSL does: last, or just before pre_shared_key which must be last
+	if f.Padding && !slices.Contains(order, ExtPadding) {
+		if i := slices.Index(order, 0x0029); i >= 0 {
+			order = slices.Insert(order, i, ExtPadding)
+		} else {
+			order = append(order, ExtPadding)
+		}
+	}
+
+	var exts cryptobyte.Builder
+	for _, ext := range order {
+		body, err := b.extensionBody(ext, rnd)
+		if err != nil {
+			return nil, fmt.Errorf("building %s extension: %w", extensionName(ext), err)
+		}
+		exts.AddUint16(ext)
+		exts.AddUint16LengthPrefixed(func(e *cryptobyte.Builder) {
+			e.AddBytes(body)
+		})
+	}
+	return exts.Bytes()
+}
+
+// extensionBody returns the contents of a single extension.  Extensions whose
+// contents we don't record are given a plausible default, or left empty
+func (b *ClientHelloBuilder) extensionBody(ext uint16, rnd io.Reader) ([]byte, error) {
+	f := b.Fingerprint
+	var e cryptobyte.Builder
+
+	switch ext {
+	case ExtServerName:
+		sni := f.SNI
+		if b.ServerName != "" {
+			sni = b.ServerName
+		}
+		e.AddUint16LengthPrefixed(func(list *cryptobyte.Builder) {
+			list.AddUint8(0) // host_name
+			list.AddUint16LengthPrefixed(func(name *cryptobyte.Builder) {
+				name.AddBytes([]byte(sni))
+			})
+		})
+	case ExtEllipticCurves:
+		e.AddUint16LengthPrefixed(addUint16s(f.ECurves))
+	case ExtECPointFormats:
+		e.AddUint8LengthPrefixed(func(list *cryptobyte.Builder) {
+			list.AddBytes(f.EcPointFmt)
+		})
+	case ExtSignatureAlgorithms, 0x0032, 0x0022: // signature_algorithms_cert, delegated_credential
+		e.AddUint16LengthPrefixed(addUint16s(f.SigAlg))
+	case ExtSupportedVersions:
+		e.AddUint8LengthPrefixed(addUint16s(f.SupportedVersions))
+	case ExtALPN:
+		e.AddUint16LengthPrefixed(addALPN(f.ALPNProtocols))
+	case 0x4469, 0x44cd: // application_settings (ALPS), old and new
+		var h2 []string
+		if slices.Contains(f.ALPNProtocols, "h2") {
+			h2 = []string{"h2"}
+		}
+		e.AddUint16LengthPrefixed(addALPN(h2))
+	case 0x0033: // key_share
+		var shares cryptobyte.Builder
+		for _, group := range f.KeyShareGroups {
+			key, err := keyShare(group, rnd)
+			if err != nil {
+				return nil, err
+			}
+			shares.AddUint16(group)
+			shares.AddUint16LengthPrefixed(func(k *cryptobyte.Builder) {
+				k.AddBytes(key)
+			})
+		}
+		raw, err := shares.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		e.AddUint16LengthPrefixed(func(list *cryptobyte.Builder) {
+			list.AddBytes(raw)
+		})
+	case 0x002d: // psk_key_exchange_modes
+		e.AddUint8LengthPrefixed(func(list *cryptobyte.Builder) {
+			list.AddBytes(f.PSKKeyExchangeModes)
+		})
+	case 0x002c: // cookie
+		e.AddUint16LengthPrefixed(func(c *cryptobyte.Builder) {
+			c.AddBytes([]byte(f.Cookie))
+		})
+	case 0xff01: // renegotiation_info
+		e.AddUint8LengthPrefixed(func(r *cryptobyte.Builder) {
+			r.AddBytes([]byte(f.RenegotiationInfo))
+		})
+	case 0x0023: // session_ticket
+		ticket, err := randomBytes(rnd, f.SessionTicketLen)
+		if err != nil {
+			return nil, err
+		}
+		e.AddBytes(ticket)
+	case 0x0005: // status_request, OCSP with no responder IDs or extensions
+		e.AddBytes([]byte{0x01, 0x00, 0x00, 0x00, 0x00})
+	case 0x001b: // compress_certificate, brotli
+		e.AddBytes([]byte{0x02, 0x00, 0x02})
+	case 0x001c: // record_size_limit
+		e.AddUint16(0x4001)
+	case 0x0029: // pre_shared_key, a single made up identity and binder
+		identity, err := randomBytes(rnd, 32)
+		if err != nil {
+			return nil, err
+		}
+		binder, err := randomBytes(rnd, 32)
+		if err != nil {
+			return nil, err
+		}
+		e.AddUint16LengthPrefixed(func(identities *cryptobyte.Builder) {
+			identities.AddUint16LengthPrefixed(func(id *cryptobyte.Builder) {
+				id.AddBytes(identity)
+			})
+			identities.AddUint32(0) // obfuscated_ticket_age
+		})
+		e.AddUint16LengthPrefixed(func(binders *cryptobyte.Builder) {
+			binders.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddBytes(binder)
+			})
+		})
+	case 0xfe0d: // encrypted_client_hello, a GREASE outer hello as Chrome sends
+		configID, err := randomBytes(rnd, 1)
+		if err != nil {
+			return nil, err
+		}
+		enc, err := randomBytes(rnd, 32)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := randomBytes(rnd, 144)
+		if err != nil {
+			return nil, err
+		}
+		e.AddUint8(0)       // outer
+		e.AddUint16(0x0001) // HKDF-SHA256
+		e.AddUint16(0x0001) // AES-128-GCM
+		e.AddBytes(configID)
+		e.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(enc) })
+		e.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(payload) })
+	case ExtPadding:
+		e.AddBytes(make([]byte, f.PaddingLen))
+	}
+	return e.Bytes()
+}
+
+func addUint16s(values []uint16) cryptobyte.BuilderContinuation {
+	return func(b *cryptobyte.Builder) {
+		for _, v := range values {
+			b.AddUint16(v)
+		}
+	}
+}
+
+func addALPN(protocols []string) cryptobyte.BuilderContinuation {
+	return func(b *cryptobyte.Builder) {
+		for _, proto := range protocols {
+			b.AddUint8LengthPrefixed(func(p *cryptobyte.Builder) {
+				p.AddBytes([]byte(proto))
+			})
+		}
+	}
+}
+
+func randomBytes(rnd io.Reader, n int) ([]byte, error) {
+	out := make([]byte, n)
+	if _, err := io.ReadFull(rnd, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// keyShare generates a fresh public key for the group.  For the groups Go
+// supports this is a real key, so that a server will accept it, otherwise
+// random bytes of the right length are used.  The private keys are discarded.
+func keyShare(group uint16, rnd io.Reader) ([]byte, error) {
+	switch group {
+	case 0x001d:
+		return ecdhShare(ecdh.X25519(), rnd)
+	case 0x0017:
+		return ecdhShare(ecdh.P256(), rnd)
+	case 0x0018:
+		return ecdhShare(ecdh.P384(), rnd)
+	case 0x0019:
+		return ecdhShare(ecdh.P521(), rnd)
+	case 0x0201:
+		return mlkem768Share(rnd)
+	case 0x0202:
+		return mlkem1024Share(rnd)
+	case 0x11ec: // X25519MLKEM768
+		return hybridShare(rnd, mlkem768Share, ecdh.X25519(), false)
+	case 0x6399: // X25519Kyber768Draft00, Kyber768 keys are the size of ML-KEM-768's
+		return hybridShare(rnd, mlkem768Share, ecdh.X25519(), true)
+	case 0x11eb: // SecP256r1MLKEM768
+		return hybridShare(rnd, mlkem768Share, ecdh.P256(), true)
+	case 0x11ed: // SecP384r1MLKEM1024
+		return hybridShare(rnd, mlkem1024Share, ecdh.P384(), true)
+	}
+
+	if isGrease(group) {
+		return randomBytes(rnd, 1)
+	}
+	size := 32
+	switch group {
+	case 0x001e: // x448
+		size = 56
+	case 0x0100, 0x0101, 0x0102, 0x0103, 0x0104: // ffdhe2048 - ffdhe8192
+		size = []int{256, 384, 512, 768, 1024}[group-0x0100]
+	case 0x0200: // MLKEM512
+		size = 800
+	}
+	return randomBytes(rnd, size)
+}
+
+func ecdhShare(curve ecdh.Curve, rnd io.Reader) ([]byte, error) {
+	key, err := curve.GenerateKey(rnd)
+	if err != nil {
+		return nil, err
+	}
+	return key.PublicKey().Bytes(), nil
+}
+
+func mlkem768Share(rnd io.Reader) ([]byte, error) {
+	seed, err := randomBytes(rnd, mlkem.SeedSize)
+	if err != nil {
+		return nil, err
+	}
+	key, err := mlkem.NewDecapsulationKey768(seed)
+	if err != nil {
+		return nil, err
+	}
+	return key.EncapsulationKey().Bytes(), nil
+}
+
+func mlkem1024Share(rnd io.Reader) ([]byte, error) {
+	seed, err := randomBytes(rnd, mlkem.SeedSize)
+	if err != nil {
+		return nil, err
+	}
+	key, err := mlkem.NewDecapsulationKey1024(seed)
+	if err != nil {
+		return nil, err
+	}
+	return key.EncapsulationKey().Bytes(), nil
+}
+
+// hybridShare concatenates a ML-KEM and ECDH share.  X25519MLKEM768 puts the
+// ML-KEM share first, the NIST curve hybrids and X25519Kyber768Draft00 put it
+// last
+func hybridShare(rnd io.Reader, kem func(io.Reader) ([]byte, error), curve ecdh.Curve, kemLast bool) ([]byte, error) {
+	kemShare, err := kem(rnd)
+	if err != nil {
+		return nil, err
+	}
+	ecShare, err := ecdhShare(curve, rnd)
+	if err != nil {
+		return nil, err
+	}
+	if kemLast {
+		return append(ecShare, kemShare...), nil
+	}
+	return append(kemShare, ecShare...), nil
+}
@@ -0,0 +1,163 @@
+package dactyloscopy_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+func TestMarshalClientHello(t *testing.T) {
+	goFP, err := dactyloscopy.ProcessClientHello(goClientHello(t, &tls.Config{
+		ServerName: "example.com",
+		NextProtos: []string{"h2", "http/1.1"},
+	}))
+	require.NoError(t, err)
+
+	chromeFP := &dactyloscopy.Fingerprint{
+		MessageType:      dactyloscopy.HandshakeType,
+		RecordTLSVersion: dactyloscopy.VersionTLS10,
+		TLSVersion:       dactyloscopy.VersionTLS12,
+		Grease:           true,
+		SessionID:        true,
+		Ciphersuite:      []uint16{0x1301, 0x1302, 0x1303, 0xc02b, 0xc02f, 0xc02c, 0xc030, 0xcca9, 0xcca8},
+		Compression:      []uint8{0},
+		Extensions: []uint16{0x3a3a, 0x0000, 0x0017, 0xff01, 0x000a, 0x000b, 0x0023, 0x0010,
+			0x0005, 0x000d, 0x0012, 0x0033, 0x002d, 0x002b, 0x001b, 0x44cd, 0xfe0d, 0x4a4a},
+		ECurves:             []uint16{0x2a2a, 0x11ec, 0x001d, 0x0017, 0x0018},
+		EcPointFmt:          []uint8{0},
+		SigAlg:              []uint16{0x0403, 0x0804, 0x0401, 0x0503, 0x0805, 0x0501, 0x0806, 0x0601},
+		SupportedVersions:   []uint16{0x5a5a, 0x0304, 0x0303},
+		ALPNProtocols:       []string{"h2", "http/1.1"},
+		KeyShareGroups:      []uint16{0x2a2a, 0x11ec, 0x001d},
+		PSKKeyExchangeModes: []uint8{1},
+		Padding:             true,
+		PaddingLen:          126,
+		SNI:                 "www.example.org",
+	}
+	require.NoError(t, chromeFP.MakeHashes())
+
+	tests := []struct {
+		name   string
+		fp     *dactyloscopy.Fingerprint
+		wantPQ dactyloscopy.GroupClass
+	}{
+		{"crypto/tls", goFP, dactyloscopy.GroupHybridPQ},
+		{"GREASE, padding and hybrid key share", chromeFP, dactyloscopy.GroupHybridPQ},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hello, err := tt.fp.MarshalClientHello()
+			require.NoError(t, err)
+			rebuilt, err := dactyloscopy.ProcessClientHello(hello)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.fp.JA3, rebuilt.JA3)
+			assert.Equal(t, tt.fp.JA3N, rebuilt.JA3N)
+			assert.Equal(t, tt.fp.JA4, rebuilt.JA4)
+			assert.Equal(t, tt.fp.Grease, rebuilt.Grease)
+			assert.Equal(t, tt.fp.SessionID, rebuilt.SessionID)
+			assert.Equal(t, tt.fp.Extensions, rebuilt.Extensions)
+			assert.Equal(t, tt.fp.ECurves, rebuilt.ECurves)
+			assert.Equal(t, tt.fp.SupportedVersions, rebuilt.SupportedVersions)
+			assert.Equal(t, tt.fp.SigAlg, rebuilt.SigAlg)
+			assert.Equal(t, tt.fp.KeyShareGroups, rebuilt.KeyShareGroups)
+			assert.Equal(t, tt.fp.PSKKeyExchangeModes, rebuilt.PSKKeyExchangeModes)
+			assert.Equal(t, tt.fp.SNI, rebuilt.SNI)
+			assert.Equal(t, tt.fp.ALPNProtocols, rebuilt.ALPNProtocols)
+			assert.Equal(t, tt.fp.Padding, rebuilt.Padding)
+			assert.Equal(t, tt.fp.PaddingLen, rebuilt.PaddingLen)
+			assert.Equal(t, tt.wantPQ, rebuilt.PQReadiness().Strongest)
+		})
+	}
+}
+
+func TestMarshalClientHelloPadding(t *testing.T) {
+	tests := []struct {
+		name       string
+		paddingLen int
+	}{
+		// BoringSSL and OpenSSL send 1 byte when just under the threshold
+		{"empty", 0},
+		{"one byte", 1},
+		{"two bytes", 2},
+		{"typical", 126},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := &dactyloscopy.Fingerprint{
+				TLSVersion:  dactyloscopy.VersionTLS12,
+				Ciphersuite: []uint16{0x1301, 0xc02b},
+				Extensions:  []uint16{0, 10, 13},
+				ECurves:     []uint16{0x001d},
+				SigAlg:      []uint16{0x0403},
+				Compression: []uint8{0},
+				Padding:     true,
+				PaddingLen:  tt.paddingLen,
+			}
+			hello, err := fp.MarshalClientHello()
+			require.NoError(t, err)
+			rebuilt, err := dactyloscopy.ProcessClientHello(hello)
+			require.NoError(t, err)
+			assert.True(t, rebuilt.Padding)
+			assert.Equal(t, tt.paddingLen, rebuilt.PaddingLen)
+			assert.Equal(t, fp.Extensions, rebuilt.Extensions)
+		})
+	}
+}
+
+func TestClientHelloBuilder(t *testing.T) {
+	fp := &dactyloscopy.Fingerprint{
+		TLSVersion:  dactyloscopy.VersionTLS12,
+		Ciphersuite: []uint16{0x1301, 0xc02b},
+		Extensions:  []uint16{0, 10, 13},
+		ECurves:     []uint16{0x001d},
+		SigAlg:      []uint16{0x0403},
+		Compression: []uint8{0},
+		SNI:         "www.example.org",
+	}
+
+	tests := []struct {
+		name    string
+		builder dactyloscopy.ClientHelloBuilder
+		wantSNI string
+		wantErr bool
+	}{
+		{
+			name:    "fingerprint's server name",
+			builder: dactyloscopy.ClientHelloBuilder{Fingerprint: fp},
+			wantSNI: "www.example.org",
+		},
+		{
+			name:    "server name override",
+			builder: dactyloscopy.ClientHelloBuilder{Fingerprint: fp, ServerName: "other.example"},
+			wantSNI: "other.example",
+		},
+		{
+			name:    "no fingerprint",
+			builder: dactyloscopy.ClientHelloBuilder{ServerName: "other.example"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hello, err := tt.builder.Build()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			rebuilt, err := dactyloscopy.ProcessClientHello(hello)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSNI, rebuilt.SNI)
+			assert.Equal(t, fp.Ciphersuite, rebuilt.Ciphersuite)
+			assert.Equal(t, fp.Extensions, rebuilt.Extensions)
+		})
+	}
+}
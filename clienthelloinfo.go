@@ -0,0 +1,78 @@
+package dactyloscopy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"slices"
+)
+
+// FromClientHelloInfo builds an approximate fingerprint from the
+// ClientHelloInfo that crypto/tls passes to GetConfigForClient and
+// GetCertificate, for servers which can't wrap their listener to see the raw
+// hello.
+//
+// crypto/tls doesn't expose the hello version, key shares or compression
+// methods, so the version is inferred and the others are listed in Unknown.
+// Older versions of Go, and ClientHelloInfo values built by hand, also have no
+// extension list, in which case the extensions, JA3 and JA3N are unknown and
+// JA4 is a partial one, with "??" for the extension count and "?" for the
+// extension hash.
+func FromClientHelloInfo(info *tls.ClientHelloInfo) (*Fingerprint, error) {
+	if info == nil {
+		return nil, fmt.Errorf("no ClientHelloInfo to fingerprint")
+	}
+	extensionsKnown := info.Extensions != nil
+
+	// crypto/tls leaves these nil when the extension wasn't sent, so they are
+	// known to be empty rather than unknown
+	c := ComponentSet{
+		Ciphersuites:        nonNil(info.CipherSuites),
+		SupportedGroups:     make([]uint16, 0, len(info.SupportedCurves)),
+		ECPointFormats:      nonNil(info.SupportedPoints),
+		SignatureAlgorithms: make([]uint16, 0, len(info.SignatureSchemes)),
+		ALPN:                nonNil(info.SupportedProtos),
+		SNI:                 info.ServerName,
+		Extensions:          info.Extensions,
+	}
+	for _, curve := range info.SupportedCurves {
+		c.SupportedGroups = append(c.SupportedGroups, uint16(curve))
+	}
+	for _, scheme := range info.SignatureSchemes {
+		c.SignatureAlgorithms = append(c.SignatureAlgorithms, uint16(scheme))
+	}
+
+	// Before TLS 1.3 crypto/tls fills the supported versions in from the
+	// hello's version, rather than from the extension, so that's the version.
+	// TLS 1.3 clients all send 1.2 as the hello version
+	sentVersions := slices.Contains(info.SupportedVersions, VersionTLS13) ||
+		(extensionsKnown && slices.Contains(info.Extensions, ExtSupportedVersions))
+	switch {
+	case sentVersions:
+		c.SupportedVersions = info.SupportedVersions
+		c.TLSVersion = VersionTLS12
+	case len(info.SupportedVersions) > 0:
+		c.TLSVersion = slices.Max(info.SupportedVersions)
+		if extensionsKnown {
+			c.SupportedVersions = []uint16{}
+		}
+	}
+
+	f, err := FromComponents(c)
+	if err != nil {
+		return nil, err
+	}
+	if !extensionsKnown && f.Known(ComponentTLSVersion) {
+		if f.JA4, err = f.ja4(info.ServerName != "", false); err != nil {
+			return nil, fmt.Errorf("error generating JA4: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// nonNil returns an empty slice in place of nil
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package dactyloscopy_test
+
+import (
+	"crypto/tls"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+// captureHelloInfo runs a handshake between a crypto/tls client and server,
+// returning the raw ClientHello the client sent and the ClientHelloInfo the
+// server was given
+func captureHelloInfo(t *testing.T, config *tls.Config) ([]byte, *tls.ClientHelloInfo) {
+	t.Helper()
+	raw := goClientHello(t, config)
+
+	client, server := net.Pipe()
+	defer client.Close() // nolint:errcheck
+	defer server.Close() // nolint:errcheck
+
+	infos := make(chan *tls.ClientHelloInfo, 1)
+	go func() {
+		_ = tls.Server(server, &tls.Config{
+			GetConfigForClient: func(info *tls.ClientHelloInfo) (*tls.Config, error) {
+				infos <- info
+				return nil, net.ErrClosed
+			},
+		}).Handshake()
+	}()
+	go func() {
+		_, _ = client.Write(raw)
+	}()
+	return raw, <-infos
+}
+
+func TestFromClientHelloInfo(t *testing.T) {
+	raw, info := captureHelloInfo(t, &tls.Config{
+		ServerName: "example.com",
+		NextProtos: []string{"h2", "http/1.1"},
+	})
+	parsed, err := dactyloscopy.ProcessClientHello(raw)
+	require.NoError(t, err)
+
+	f, err := dactyloscopy.FromClientHelloInfo(info)
+	require.NoError(t, err)
+
+	// With the extension list from crypto/tls, this is as good as parsing
+	assert.Equal(t, parsed.JA3, f.JA3)
+	assert.Equal(t, parsed.JA3N, f.JA3N)
+	assert.Equal(t, parsed.JA4, f.JA4)
+	assert.Equal(t, parsed.SigAlg, f.SigAlg)
+	assert.Equal(t, parsed.SupportedVersions, f.SupportedVersions)
+	assert.Equal(t, "example.com", f.SNI)
+	assert.False(t, f.Known(dactyloscopy.ComponentKeyShares))
+	assert.False(t, f.Known(dactyloscopy.ComponentCompression))
+	assert.True(t, f.Known(dactyloscopy.ComponentExtensions))
+}
+
+func TestFromClientHelloInfoNoExtensions(t *testing.T) {
+	raw, info := captureHelloInfo(t, &tls.Config{
+		ServerName: "example.com",
+		NextProtos: []string{"h2"},
+	})
+	parsed, err := dactyloscopy.ProcessClientHello(raw)
+	require.NoError(t, err)
+
+	info.Extensions = nil
+	f, err := dactyloscopy.FromClientHelloInfo(info)
+	require.NoError(t, err)
+
+	assert.False(t, f.Known(dactyloscopy.ComponentExtensions))
+	assert.False(t, f.Known(dactyloscopy.ComponentExtensionOrder))
+	assert.Empty(t, f.JA3)
+	assert.Equal(t, parsed.TLSVersion, f.TLSVersion)
+	assert.Equal(t, parsed.SigAlg, f.SigAlg)
+
+	// The partial JA4 matches on everything but the extensions
+	full := strings.Split(parsed.JA4, ",")
+	partial := strings.Split(f.JA4, ",")
+	require.Len(t, partial, 4)
+	assert.Equal(t, full[0][:6]+"??"+full[0][8:], partial[0])
+	assert.Equal(t, full[1], partial[1])
+	assert.Equal(t, "?", partial[2])
+	assert.Equal(t, full[3], partial[3])
+
+	_, err = dactyloscopy.FromClientHelloInfo(nil)
+	assert.Error(t, err)
+}
+
+func TestFromClientHelloInfoTLS12(t *testing.T) {
+	f, err := dactyloscopy.FromClientHelloInfo(&tls.ClientHelloInfo{
+		CipherSuites:      []uint16{0xc02b, 0xc02f},
+		SupportedVersions: []uint16{dactyloscopy.VersionTLS12, dactyloscopy.VersionTLS11},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dactyloscopy.VersionTLS12, f.TLSVersion)
+	assert.False(t, f.Known(dactyloscopy.ComponentSupportedVersions))
+	assert.True(t, strings.HasPrefix(f.JA4, "t12i02??-,"), f.JA4)
+}
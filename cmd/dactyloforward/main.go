@@ -0,0 +1,170 @@
+// Command dactyloforward is an egress proxy, speaking HTTP CONNECT and SOCKS5
+// on the same port, which fingerprints the TLS clients tunnelling through it
+// without decrypting anything.  Each tunnel is logged as a JSON record with the
+// client's identity, its destination, the fingerprint of its ClientHello and
+// the bytes transferred each way:
+//
+//	dactyloforward -listen 10.0.0.1:3128 -users users.txt \
+//	    -allow 10.20.0.0/16 -deny 10.20.99.0/24 -db fingerprints.json
+//
+// The users file has a name:password line for each client, blank lines and
+// lines starting with # are skipped.  Clients must authenticate, by Basic
+// Proxy-Authorization or SOCKS5 username/password, and are logged by username.
+// Without a users file the proxy is open, so it only listens on loopback
+// addresses.  It listens on 127.0.0.1:3128 by default.
+//
+// Tunnels may only reach public addresses, checked after name resolution.
+// -allow lets them reach internal prefixes too, and -deny refuses prefixes
+// even if they are public or allowed.  Both may be repeated.
+//
+// Clients matching an entry in the -db fingerprint DB, in the format
+// dactyloscopy.LoadFingerprintDBFile reads, are logged with its name and tags,
+// so unapproved software can be picked out by its TLS stack.
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+	"github.com/LeeBrotherston/dactyloscopy/internal/fplog"
+)
+
+// users maps client names to passwords
+type users map[string]string
+
+// loadUsers reads a users file of name:password lines
+func loadUsers(path string) (users, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is from the command line
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+
+	u := users{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, password, ok := strings.Cut(text, ":")
+		if !ok || name == "" || password == "" {
+			return nil, fmt.Errorf("%s:%d: not name:password", path, line)
+		}
+		u[name] = password
+	}
+	return u, scanner.Err()
+}
+
+// authenticate checks a client's credentials against the users
+func (u users) authenticate(username, password string) (string, error) {
+	want, ok := u[username]
+	if !ok || subtle.ConstantTimeCompare([]byte(password), []byte(want)) != 1 {
+		return "", errors.New("bad username or password")
+	}
+	return username, nil
+}
+
+// prefixFlags collects the -allow and -deny flags, each a prefix or a single
+// address
+type prefixFlags []netip.Prefix
+
+func (p *prefixFlags) String() string {
+	prefixes := make([]string, 0, len(*p))
+	for _, prefix := range *p {
+		prefixes = append(prefixes, prefix.String())
+	}
+	return strings.Join(prefixes, ",")
+}
+
+func (p *prefixFlags) Set(value string) error {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		addr, addrErr := netip.ParseAddr(value)
+		if addrErr != nil {
+			return fmt.Errorf("%q isn't a prefix or address", value)
+		}
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	*p = append(*p, prefix.Masked())
+	return nil
+}
+
+// isLoopback reports whether the listen address only accepts local clients
+func isLoopback(listen string) bool {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && addr.IsLoopback()
+}
+
+func main() {
+	var allow, deny prefixFlags
+	listen := flag.String("listen", "127.0.0.1:3128", "address to listen on")
+	usersPath := flag.String("users", "", "file of name:password lines for the clients")
+	flag.Var(&allow, "allow", "internal prefix tunnels may reach, may be repeated")
+	flag.Var(&deny, "deny", "prefix tunnels may not reach, may be repeated")
+	dbPath := flag.String("db", "", "JSON fingerprint DB")
+	flag.Parse()
+
+	proxy := &interceptls.ForwardProxy{AllowDestination: interceptls.DestinationRules(allow, deny)}
+	var clients users
+	if *usersPath != "" {
+		var err error
+		if clients, err = loadUsers(*usersPath); err != nil {
+			log.Fatal(err)
+		}
+		proxy.Authenticate = clients.authenticate
+	} else if !isLoopback(*listen) {
+		log.Fatalf("refusing to run an open proxy on %s, give a -users file", *listen)
+	}
+
+	db, err := fplog.LoadDB(*dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	proxy.Log = func(record interceptls.TunnelRecord) { logRecord(logger, db, record) }
+	proxy.AcceptError = func(err error) { logger.Warn("accept", "error", err.Error()) }
+	logger.Info("listening", "addr", *listen, "users", len(clients), "fingerprints", len(db.Entries))
+	log.Fatal(proxy.ListenAndServe(*listen))
+}
+
+// logRecord logs a tunnel, with the client's DB entry if it has one
+func logRecord(logger *slog.Logger, db *dactyloscopy.FingerprintDB, record interceptls.TunnelRecord) {
+	attrs := []slog.Attr{
+		slog.String("remote", record.Remote.String()),
+		slog.String("protocol", record.Protocol),
+		slog.String("user", record.User),
+		slog.String("destination", record.Destination),
+		slog.Bool("tls", record.TLS),
+		slog.Int64("bytes_up", record.BytesUp),
+		slog.Int64("bytes_down", record.BytesDown),
+		slog.Duration("duration", record.Duration),
+	}
+	if record.TLS {
+		attrs = append(attrs, fplog.Attrs(db, &record.Fingerprint, record.HelloErr)...)
+	}
+	if record.Err != nil {
+		attrs = append(attrs, slog.String("error", record.Err.Error()))
+	}
+	logger.LogAttrs(context.Background(), slog.LevelInfo, "tunnel", attrs...)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+func TestLoadUsers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# clients\nalice:secret\n\nbob:pass:word\n"), 0o600))
+	u, err := loadUsers(path)
+	require.NoError(t, err)
+	assert.Equal(t, users{"alice": "secret", "bob": "pass:word"}, u)
+
+	user, err := u.authenticate("bob", "pass:word")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", user)
+	_, err = u.authenticate("alice", "wrong")
+	assert.Error(t, err)
+	_, err = u.authenticate("carol", "")
+	assert.Error(t, err)
+
+	for _, bad := range []string{"alice", ":secret", "alice:"} {
+		require.NoError(t, os.WriteFile(path, []byte("alice:secret\n"+bad+"\n"), 0o600))
+		_, err := loadUsers(path)
+		assert.EqualError(t, err, path+":2: not name:password", bad)
+	}
+}
+
+func TestPrefixFlags(t *testing.T) {
+	var prefixes prefixFlags
+	require.NoError(t, prefixes.Set("10.1.2.3/16"))
+	require.NoError(t, prefixes.Set("192.0.2.1"))
+	require.NoError(t, prefixes.Set("fd00::/8"))
+	assert.Equal(t, "10.1.0.0/16,192.0.2.1/32,fd00::/8", prefixes.String())
+	assert.Error(t, prefixes.Set("example.com"))
+}
+
+func TestIsLoopback(t *testing.T) {
+	for listen, want := range map[string]bool{
+		"127.0.0.1:3128": true,
+		"[::1]:3128":     true,
+		"localhost:3128": true,
+		":3128":          false,
+		"0.0.0.0:3128":   false,
+		"10.0.0.1:3128":  false,
+		"127.0.0.1":      false,
+	} {
+		assert.Equal(t, want, isLoopback(listen), listen)
+	}
+}
+
+func TestLogRecord(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	db := dactyloscopy.NewFingerprintDB([]dactyloscopy.DBEntry{{Name: "Test client", JA4: "ja4", Tags: []string{"unapproved"}}})
+
+	logRecord(logger, db, interceptls.TunnelRecord{
+		Remote:      &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 1234},
+		Protocol:    interceptls.TunnelConnect,
+		User:        "alice",
+		Destination: "www.example.com:443",
+		TLS:         true,
+		Fingerprint: dactyloscopy.Fingerprint{SNI: "www.example.com", JA3: "ja3", JA4: "ja4"},
+		BytesUp:     517,
+		BytesDown:   4096,
+		Duration:    1500 * time.Millisecond,
+	})
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	delete(record, "time")
+	assert.Equal(t, map[string]any{
+		"level":       "INFO",
+		"msg":         "tunnel",
+		"remote":      "192.0.2.1:1234",
+		"protocol":    "connect",
+		"user":        "alice",
+		"destination": "www.example.com:443",
+		"tls":         true,
+		"sni":         "www.example.com",
+		"ja3":         "ja3",
+		"ja3n":        "",
+		"ja4":         "ja4",
+		"bytes_up":    float64(517),
+		"bytes_down":  float64(4096),
+		"duration":    float64(1500 * time.Millisecond),
+		"client":      "Test client",
+		"matched_on":  "ja4",
+		"tags":        []any{"unapproved"},
+	}, record)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+// defaultTarpit is how long the tarpit action holds connections, when the
+// rule doesn't say
+const defaultTarpit = 10 * time.Second
+
+// config is the JSON config file, see the package documentation
+type config struct {
+	Listen        string                          `json:"listen"`
+	Cert          string                          `json:"cert"`
+	Key           string                          `json:"key"`
+	DB            string                          `json:"db,omitempty"`
+	Upstreams     []upstream                      `json:"upstreams"`
+	Rules         []rule                          `json:"rules,omitempty"`
+	DefaultAction string                          `json:"default_action,omitempty"`
+	Headers       *interceptls.FingerprintHeaders `json:"headers,omitempty"`
+
+	defaultRule rule
+}
+
+// upstream is where requests for a host and path are forwarded
+type upstream struct {
+	// Host matches the request's host, without the port.  Empty matches any
+	Host string `json:"host,omitempty"`
+	// PathPrefix matches the start of the request's path.  Empty matches any
+	PathPrefix string `json:"path_prefix,omitempty"`
+	URL        string `json:"url"`
+
+	target *url.URL
+}
+
+// rule decides what to do with clients.  A rule matches when all of its
+// criteria do, so one without any matches every client
+type rule struct {
+	// Action is allow, deny (close the connection), reset (close with a TCP
+	// RST) or tarpit (hold the connection open for Delay, then close it)
+	Action string `json:"action"`
+	Delay  string `json:"delay,omitempty"`
+
+	SNI  string `json:"sni,omitempty"`
+	JA3  string `json:"ja3,omitempty"`
+	JA3N string `json:"ja3n,omitempty"`
+	JA4  string `json:"ja4,omitempty"`
+	// Name and Tag match the client's fingerprint DB entry
+	Name string `json:"name,omitempty"`
+	Tag  string `json:"tag,omitempty"`
+	// Malformed matches ClientHellos which couldn't be parsed
+	Malformed bool `json:"malformed,omitempty"`
+
+	decision interceptls.PolicyDecision
+}
+
+func loadConfig(path string) (*config, error) {
+	file, err := os.Open(path) // #nosec G304 -- path is supplied by the operator
+	if err != nil {
+		return nil, fmt.Errorf("opening config: %w", err)
+	}
+	defer file.Close() // nolint:errcheck
+
+	var cfg config
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("decoding config: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// validate checks the config, and fills in the defaults
+func (cfg *config) validate() error {
+	if cfg.Listen == "" {
+		cfg.Listen = ":8443"
+	}
+	if cfg.Cert == "" || cfg.Key == "" {
+		return fmt.Errorf("cert and key are required")
+	}
+	if len(cfg.Upstreams) == 0 {
+		return fmt.Errorf("at least one upstream is required")
+	}
+	for i := range cfg.Upstreams {
+		u := &cfg.Upstreams[i]
+		target, err := url.Parse(u.URL)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return fmt.Errorf("upstream %d: invalid url %q", i, u.URL)
+		}
+		u.target = target
+	}
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].parse(); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+	cfg.defaultRule = rule{Action: cmp.Or(cfg.DefaultAction, "allow")}
+	if err := cfg.defaultRule.parse(); err != nil {
+		return fmt.Errorf("default_action: %w", err)
+	}
+	if cfg.Headers == nil {
+		headers := interceptls.DefaultFingerprintHeaders
+		cfg.Headers = &headers
+	}
+	return nil
+}
+
+func (r *rule) parse() error {
+	switch r.Action {
+	case "allow":
+		r.decision = interceptls.PolicyDecision{Action: interceptls.PolicyAccept}
+	case "deny":
+		r.decision = interceptls.PolicyDecision{Action: interceptls.PolicyClose}
+	case "reset":
+		r.decision = interceptls.PolicyDecision{Action: interceptls.PolicyReset}
+	case "tarpit":
+		delay := defaultTarpit
+		if r.Delay != "" {
+			var err error
+			if delay, err = time.ParseDuration(r.Delay); err != nil {
+				return fmt.Errorf("invalid delay: %w", err)
+			}
+		}
+		r.decision = interceptls.PolicyDecision{Action: interceptls.PolicyDelay, Delay: delay}
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	return nil
+}
+
+// matches checks the client against the rule's criteria
+func (r *rule) matches(hello interceptls.HelloInfo, match dactyloscopy.DBMatch) bool {
+	fp := hello.Fingerprint
+	for _, criterion := range []struct{ want, got string }{
+		{r.JA3, fp.JA3},
+		{r.JA3N, fp.JA3N},
+		{r.JA4, fp.JA4},
+		{r.Name, match.Name},
+	} {
+		if criterion.want != "" && criterion.want != criterion.got {
+			return false
+		}
+	}
+	if r.SNI != "" && !strings.EqualFold(r.SNI, fp.SNI) {
+		return false
+	}
+	if r.Tag != "" && !slices.Contains(match.Tags, r.Tag) {
+		return false
+	}
+	return !r.Malformed || hello.Err != nil
+}
@@ -0,0 +1,74 @@
+// Command dactyloproxy is a TLS terminating reverse proxy which fingerprints
+// each client.  It turns clients away by fingerprint before the handshake,
+// forwards requests to HTTP upstreams with the fingerprints in headers, and
+// logs each connection as a JSON record.
+//
+// It is configured with a JSON file:
+//
+//	{
+//	    "listen": ":8443",
+//	    "cert": "/etc/dactyloproxy/cert.pem",
+//	    "key": "/etc/dactyloproxy/key.pem",
+//	    "db": "/etc/dactyloproxy/fingerprints.json",
+//	    "upstreams": [
+//	        {"host": "api.example.com", "url": "http://10.0.0.2:8080"},
+//	        {"path_prefix": "/static/", "url": "http://10.0.0.3:8080"},
+//	        {"url": "http://10.0.0.1:8080"}
+//	    ],
+//	    "rules": [
+//	        {"action": "tarpit", "tag": "scanner", "delay": "30s"},
+//	        {"action": "reset", "malformed": true},
+//	        {"action": "deny", "name": "curl"},
+//	        {"action": "allow", "sni": "api.example.com"}
+//	    ],
+//	    "default_action": "allow"
+//	}
+//
+// Requests go to the first upstream whose host and path_prefix match, either
+// of which may be left out.  The first rule whose criteria (sni, ja3, ja3n,
+// ja4, the name or a tag of the client's DB entry, or malformed for
+// ClientHellos which couldn't be parsed) all match decides what happens:
+// allow, deny (close the connection), reset (close it with a TCP RST) or
+// tarpit (hold it open for delay, 10s by default, then close it).  Clients no
+// rule matches get default_action, allow unless set.
+//
+// The fingerprint headers default to interceptls.DefaultFingerprintHeaders.
+// A headers object replaces them, mapping any of ja3, ja3n, ja4, ja4h, http2,
+// sni, alpn, json and raw_hello to the header name to send it in.  Any the
+// client sends are removed.
+//
+// The db file is a fingerprint DB in the format
+// dactyloscopy.LoadFingerprintDBFile reads.
+package main
+
+import (
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/LeeBrotherston/dactyloscopy/internal/fplog"
+)
+
+func main() {
+	configPath := flag.String("config", "dactyloproxy.json", "JSON config file")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := fplog.LoadDB(cfg.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	server, ln, err := newProxy(cfg, db, logger).listen()
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger.Info("listening", "addr", ln.Addr().String(), "fingerprints", len(db.Entries))
+	log.Fatal(server.Serve(ln))
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+// writeCert writes a self-signed cert and key to dir, returning their paths
+func writeCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certPath, keyPath
+}
+
+// logBuffer collects the JSON log records
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) records() []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		var record map[string]any
+		if json.Unmarshal([]byte(line), &record) == nil {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// waitForRecord waits for a connection log record with the given SNI
+func (b *logBuffer) waitForRecord(t *testing.T, sni string) map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, record := range b.records() {
+			if record["msg"] == "connection" && record["sni"] == sni {
+				return record
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no connection record for %s", sni)
+	return nil
+}
+
+func TestProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(r.Header)
+	}))
+	defer backend.Close()
+
+	certPath, keyPath := writeCert(t, t.TempDir())
+	cfg := &config{
+		Listen:    "127.0.0.1:0",
+		Cert:      certPath,
+		Key:       keyPath,
+		Upstreams: []upstream{{URL: backend.URL}},
+		Rules:     []rule{{Action: "deny", SNI: "blocked.example"}},
+	}
+	require.NoError(t, cfg.validate())
+
+	logs := &logBuffer{}
+	server, ln, err := newProxy(cfg, dactyloscopy.NewFingerprintDB(nil), slog.New(slog.NewJSONHandler(logs, nil))).listen()
+	require.NoError(t, err)
+	go server.Serve(ln)  // nolint:errcheck
+	defer server.Close() // nolint:errcheck
+
+	// get returns the headers the backend saw.  The body is read before the
+	// idle connection is closed, so the proxy logs the connection as it ends
+	get := func(sni string) (http.Header, error) {
+		transport := &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true, ServerName: sni}, // #nosec G402 -- self-signed test cert
+			ForceAttemptHTTP2: true,
+		}
+		defer transport.CloseIdleConnections()
+		req, err := http.NewRequest("GET", "https://"+ln.Addr().String()+"/path", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-JA4-Fingerprint", "spoofed")
+		resp, err := (&http.Client{Transport: transport, Timeout: 5 * time.Second}).Do(req)
+		if err != nil {
+			return nil, err
+		}
+		var headers http.Header
+		err = json.NewDecoder(resp.Body).Decode(&headers)
+		return headers, errors.Join(err, resp.Body.Close())
+	}
+
+	headers, err := get("allowed.example")
+	require.NoError(t, err)
+	assert.Equal(t, "allowed.example", headers.Get("X-TLS-SNI"))
+	assert.NotEmpty(t, headers.Get("X-JA4-Fingerprint"))
+	assert.NotEqual(t, "spoofed", headers.Get("X-JA4-Fingerprint"))
+
+	record := logs.waitForRecord(t, "allowed.example")
+	assert.Equal(t, "allow", record["action"])
+	assert.Equal(t, "h2", record["alpn"])
+	assert.Equal(t, float64(1), record["requests"])
+	assert.Equal(t, headers.Get("X-JA4-Fingerprint"), record["ja4"])
+
+	_, err = get("blocked.example")
+	assert.Error(t, err)
+	record = logs.waitForRecord(t, "blocked.example")
+	assert.Equal(t, "deny", record["action"])
+	assert.NotEmpty(t, record["ja4"])
+}
+
+func TestRuleMatches(t *testing.T) {
+	hello := interceptls.HelloInfo{Fingerprint: dactyloscopy.Fingerprint{SNI: "example.com", JA4: "ja4"}}
+	match := dactyloscopy.DBMatch{DBEntry: dactyloscopy.DBEntry{Name: "scanner", Tags: []string{"bad", "tool"}}}
+
+	for _, tc := range []struct {
+		rule    rule
+		matches bool
+	}{
+		{rule{}, true},
+		{rule{SNI: "EXAMPLE.com", JA4: "ja4"}, true},
+		{rule{SNI: "example.com", JA4: "other"}, false},
+		{rule{Name: "scanner", Tag: "bad"}, true},
+		{rule{Tag: "good"}, false},
+		{rule{Malformed: true}, false},
+	} {
+		assert.Equal(t, tc.matches, tc.rule.matches(hello, match), "%+v", tc.rule)
+	}
+
+	hello.Err = errors.New("malformed")
+	assert.True(t, (&rule{Malformed: true}).matches(hello, dactyloscopy.DBMatch{}))
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	cfg, err := loadConfig(write(`{
+		"cert": "cert.pem", "key": "key.pem",
+		"upstreams": [{"url": "http://127.0.0.1:8080"}],
+		"rules": [{"action": "tarpit", "tag": "scanner"}],
+		"headers": {"ja4": "X-Client-JA4"}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, ":8443", cfg.Listen)
+	assert.Equal(t, interceptls.PolicyDecision{Action: interceptls.PolicyDelay, Delay: defaultTarpit}, cfg.Rules[0].decision)
+	assert.Equal(t, interceptls.PolicyAccept, cfg.defaultRule.decision.Action)
+	assert.Equal(t, &interceptls.FingerprintHeaders{JA4: "X-Client-JA4"}, cfg.Headers)
+
+	for content, want := range map[string]string{
+		`{"cert": "c", "key": "k", "upstreams": [{"url": "http://a"}], "rules": [{"action": "block"}]}`: `rule 0: unknown action "block"`,
+		`{"cert": "c", "key": "k", "upstreams": [{"url": "a"}]}`:                                        `upstream 0: invalid url "a"`,
+		`{"cert": "c", "key": "k"}`: "at least one upstream is required",
+		`{"cert": "c", "key": "k", "upstreams": [{"url": "http://a"}], "listn": ":1"}`: `unknown field "listn"`,
+	} {
+		_, err := loadConfig(write(content))
+		assert.ErrorContains(t, err, want)
+	}
+}
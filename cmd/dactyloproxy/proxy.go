@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+	"github.com/LeeBrotherston/dactyloscopy/internal/fplog"
+)
+
+// proxy applies the rules to each client, forwards their requests upstream,
+// and logs each connection
+type proxy struct {
+	cfg    *config
+	db     *dactyloscopy.FingerprintDB
+	log    *slog.Logger
+	routes []route
+
+	// conns holds the stats of open connections, by net.Conn
+	conns sync.Map
+}
+
+type route struct {
+	upstream
+	handler http.Handler
+}
+
+// connStats is what's logged when a connection closes
+type connStats struct {
+	start    time.Time
+	requests atomic.Int64
+}
+
+type contextKey string
+
+const connStatsKey contextKey = "connStats"
+
+func newProxy(cfg *config, db *dactyloscopy.FingerprintDB, logger *slog.Logger) *proxy {
+	p := &proxy{cfg: cfg, db: db, log: logger}
+	for _, u := range cfg.Upstreams {
+		p.routes = append(p.routes, route{
+			upstream: u,
+			handler:  interceptls.NewReverseProxy(u.target, *cfg.Headers),
+		})
+	}
+	return p
+}
+
+// listen opens the listener and returns the server to serve it
+func (p *proxy) listen() (*http.Server, net.Listener, error) {
+	cert, err := tls.LoadX509KeyPair(p.cfg.Cert, p.cfg.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	ln, err := net.Listen("tcp", p.cfg.Listen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrapped := interceptls.NewInterceptListener(ln, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+	})
+	wrapped.Policy = p.policy
+	wrapped.HelloError = func(addr net.Addr, err error) {
+		p.log.Info("connection", "remote", addr.String(), "action", "drop", "error", err.Error())
+	}
+	wrapped.AcceptError = func(err error) {
+		p.log.Warn("accept", "error", err.Error())
+	}
+
+	server := &http.Server{
+		Handler:           p,
+		ConnContext:       p.connContext,
+		ConnState:         p.connState,
+		ReadHeaderTimeout: 30 * time.Second,
+		ErrorLog:          slog.NewLogLogger(p.log.Handler(), slog.LevelWarn),
+	}
+	return server, wrapped, nil
+}
+
+// policy applies the first matching rule.  Connections which are turned away
+// are logged here, as the server never sees them
+func (p *proxy) policy(hello interceptls.HelloInfo, addr net.Addr) interceptls.PolicyDecision {
+	match, _ := p.db.Lookup(&hello.Fingerprint)
+	r := &p.cfg.defaultRule
+	for i := range p.cfg.Rules {
+		if p.cfg.Rules[i].matches(hello, match) {
+			r = &p.cfg.Rules[i]
+			break
+		}
+	}
+	if r.decision.Action != interceptls.PolicyAccept {
+		attrs := append(p.helloAttrs(hello), slog.String("remote", addr.String()), slog.String("action", r.Action))
+		p.log.LogAttrs(context.Background(), slog.LevelInfo, "connection", attrs...)
+	}
+	return r.decision
+}
+
+func (p *proxy) connContext(ctx context.Context, c net.Conn) context.Context {
+	stats := &connStats{start: time.Now()}
+	p.conns.Store(c, stats)
+	ctx = interceptls.ConnContextHandler(ctx, c)
+	return context.WithValue(ctx, connStatsKey, stats)
+}
+
+// connState logs connections as they close
+func (p *proxy) connState(c net.Conn, state http.ConnState) {
+	if state != http.StateClosed && state != http.StateHijacked {
+		return
+	}
+	value, ok := p.conns.LoadAndDelete(c)
+	if !ok {
+		return
+	}
+	stats := value.(*connStats)
+
+	attrs := []slog.Attr{
+		slog.String("remote", c.RemoteAddr().String()),
+		slog.String("action", "allow"),
+		slog.Int64("requests", stats.requests.Load()),
+		slog.Duration("duration", time.Since(stats.start)),
+	}
+	if hc, ok := c.(*interceptls.HelloConn); ok {
+		attrs = append(attrs, p.helloAttrs(interceptls.HelloInfo{Fingerprint: *hc.DactFP, Err: hc.HelloErr})...)
+		if alpn := hc.ConnectionState().NegotiatedProtocol; alpn != "" {
+			attrs = append(attrs, slog.String("alpn", alpn))
+		}
+	}
+	p.log.LogAttrs(context.Background(), slog.LevelInfo, "connection", attrs...)
+}
+
+// helloAttrs are the log attributes describing the client
+func (p *proxy) helloAttrs(hello interceptls.HelloInfo) []slog.Attr {
+	return fplog.Attrs(p.db, &hello.Fingerprint, hello.Err)
+}
+
+// ServeHTTP forwards the request to the first upstream which matches it
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if stats, ok := r.Context().Value(connStatsKey).(*connStats); ok {
+		stats.requests.Add(1)
+	}
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, rt := range p.routes {
+		if rt.Host != "" && !strings.EqualFold(rt.Host, host) {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, rt.PathPrefix) {
+			continue
+		}
+		rt.handler.ServeHTTP(w, r)
+		return
+	}
+	http.Error(w, "no upstream", http.StatusBadGateway)
+}
@@ -0,0 +1,94 @@
+// Command dactylosni is a TLS passthrough proxy which fingerprints each client
+// and routes it to a backend by SNI, without terminating TLS, so it needs no
+// certificates or keys.  Each connection is logged as a JSON record with the
+// client's fingerprint, the bytes transferred each way and its duration:
+//
+//	dactylosni -listen :443 -route 'www.example.com=10.0.0.1:443' \
+//	    -route '*.example.com=10.0.0.2:443' -default 10.0.0.3:443 \
+//	    -db fingerprints.json
+//
+// Routes are tried by exact SNI, then by wildcard.  A wildcard covers a single
+// label, so '*.example.com' matches www.example.com but not a.b.example.com.
+// Clients which match no route go to the default backend, or are dropped if
+// there isn't one.
+//
+// Clients matching an entry in the -db fingerprint DB, in the format
+// dactyloscopy.LoadFingerprintDBFile reads, are logged with its name and tags.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+	"github.com/LeeBrotherston/dactyloscopy/internal/fplog"
+)
+
+// routeFlags collects the -route flags
+type routeFlags map[string]string
+
+func (r routeFlags) String() string {
+	routes := make([]string, 0, len(r))
+	for sni, backend := range r {
+		routes = append(routes, sni+"="+backend)
+	}
+	return strings.Join(routes, ",")
+}
+
+func (r routeFlags) Set(value string) error {
+	sni, backend, ok := strings.Cut(value, "=")
+	if !ok || sni == "" || backend == "" {
+		return fmt.Errorf("route %q isn't sni=backend", value)
+	}
+	r[sni] = backend
+	return nil
+}
+
+func main() {
+	routes := routeFlags{}
+	listen := flag.String("listen", ":443", "address to listen on")
+	flag.Var(routes, "route", "sni=backend route, may be repeated")
+	fallback := flag.String("default", "", "backend for clients which match no route")
+	dbPath := flag.String("db", "", "JSON fingerprint DB")
+	flag.Parse()
+
+	if len(routes) == 0 && *fallback == "" {
+		log.Fatal("no routes or default backend")
+	}
+
+	db, err := fplog.LoadDB(*dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	proxy := &interceptls.Passthrough{
+		Route:       interceptls.RouteBySNI(routes, *fallback),
+		Log:         func(record interceptls.PassthroughRecord) { logRecord(logger, db, record) },
+		AcceptError: func(err error) { logger.Warn("accept", "error", err.Error()) },
+	}
+	logger.Info("listening", "addr", *listen, "routes", len(routes), "fingerprints", len(db.Entries))
+	log.Fatal(proxy.ListenAndServe(*listen))
+}
+
+// logRecord logs a connection, with the client's DB entry if it has one
+func logRecord(logger *slog.Logger, db *dactyloscopy.FingerprintDB, record interceptls.PassthroughRecord) {
+	attrs := []slog.Attr{
+		slog.String("remote", record.Remote.String()),
+		slog.String("backend", record.Backend),
+		slog.Int64("bytes_up", record.BytesUp),
+		slog.Int64("bytes_down", record.BytesDown),
+		slog.Duration("duration", record.Duration),
+	}
+	attrs = append(attrs, fplog.Attrs(db, &record.Fingerprint, record.HelloErr)...)
+	if record.Err != nil {
+		attrs = append(attrs, slog.String("error", record.Err.Error()))
+	}
+	logger.LogAttrs(context.Background(), slog.LevelInfo, "connection", attrs...)
+}
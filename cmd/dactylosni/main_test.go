@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+func TestRouteFlags(t *testing.T) {
+	routes := routeFlags{}
+	require.NoError(t, routes.Set("www.example.com=10.0.0.1:443"))
+	require.NoError(t, routes.Set("*.example.com=10.0.0.2:443"))
+	assert.Equal(t, routeFlags{"www.example.com": "10.0.0.1:443", "*.example.com": "10.0.0.2:443"}, routes)
+
+	for _, bad := range []string{"10.0.0.1:443", "=10.0.0.1:443", "www.example.com="} {
+		assert.Error(t, routes.Set(bad), bad)
+	}
+}
+
+func TestLogRecord(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	db := dactyloscopy.NewFingerprintDB([]dactyloscopy.DBEntry{{Name: "Test client", JA4: "ja4", Tags: []string{"test"}}})
+
+	logRecord(logger, db, interceptls.PassthroughRecord{
+		Remote:      &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 1234},
+		Backend:     "10.0.0.1:443",
+		Fingerprint: dactyloscopy.Fingerprint{SNI: "www.example.com", JA3: "ja3", JA4: "ja4"},
+		BytesUp:     517,
+		BytesDown:   4096,
+		Duration:    1500 * time.Millisecond,
+	})
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	delete(record, "time")
+	assert.Equal(t, map[string]any{
+		"level":      "INFO",
+		"msg":        "connection",
+		"remote":     "192.0.2.1:1234",
+		"backend":    "10.0.0.1:443",
+		"sni":        "www.example.com",
+		"ja3":        "ja3",
+		"ja3n":       "",
+		"ja4":        "ja4",
+		"bytes_up":   float64(517),
+		"bytes_down": float64(4096),
+		"duration":   float64(1500 * time.Millisecond),
+		"client":     "Test client",
+		"matched_on": "ja4",
+		"tags":       []any{"test"},
+	}, record)
+}
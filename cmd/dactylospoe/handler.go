@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/spoe"
+)
+
+// fingerprinter handles the fingerprint message sent by HAProxy, setting
+// variables with the fingerprint and the matching DB entry, if any
+type fingerprinter struct {
+	message string
+	scope   spoe.Scope
+	db      *dactyloscopy.FingerprintDB
+}
+
+func (h *fingerprinter) handle(messages []spoe.Message) []spoe.Action {
+	var actions []spoe.Action
+	for _, m := range messages {
+		if m.Name != h.message {
+			continue
+		}
+		actions = append(actions, h.fingerprint(m)...)
+	}
+	return actions
+}
+
+func (h *fingerprinter) fingerprint(m spoe.Message) []spoe.Action {
+	f, err := fromMessage(m)
+	if err != nil {
+		return []spoe.Action{spoe.SetVar(h.scope, "fp_error", err.Error())}
+	}
+
+	// A hash is left unset when it couldn't be calculated, e.g. JA4 needs the
+	// ALPN list, which HAProxy can't capture
+	var actions []spoe.Action
+	for _, hash := range []struct{ name, value string }{
+		{"ja3", f.JA3},
+		{"ja3n", f.JA3N},
+		{"ja4", f.JA4},
+	} {
+		if hash.value != "" {
+			actions = append(actions, spoe.SetVar(h.scope, hash.name, hash.value))
+		}
+	}
+	match, ok := h.db.Lookup(f)
+	actions = append(actions, spoe.SetVar(h.scope, "fp_known", ok))
+	if ok {
+		actions = append(actions,
+			spoe.SetVar(h.scope, "fp_name", match.Name),
+			spoe.SetVar(h.scope, "fp_matched_on", match.MatchedOn),
+			spoe.SetVar(h.scope, "fp_tags", strings.Join(match.Tags, ",")),
+		)
+	}
+	return actions
+}
+
+// fromMessage builds a fingerprint from the message arguments, see the
+// package documentation for their names
+func fromMessage(m spoe.Message) (*dactyloscopy.Fingerprint, error) {
+	var (
+		c   dactyloscopy.ComponentSet
+		err error
+	)
+	if v, ok := m.Arg("version"); ok {
+		version, ok := asInt(v)
+		if !ok || version > 0xffff {
+			return nil, fmt.Errorf("invalid version %v", v)
+		}
+		c.TLSVersion = uint16(version)
+	}
+	if c.Ciphersuites, err = list16(m, "ciphers"); err != nil {
+		return nil, err
+	}
+	if c.Extensions, err = list16(m, "extensions"); err != nil {
+		return nil, err
+	}
+	if c.SupportedGroups, err = list16(m, "groups"); err != nil {
+		return nil, err
+	}
+	if c.SignatureAlgorithms, err = list16(m, "sigalgs"); err != nil {
+		return nil, err
+	}
+	if c.SupportedVersions, err = list16(m, "versions"); err != nil {
+		return nil, err
+	}
+	if v, ok := m.Arg("ecformats"); ok {
+		if c.ECPointFormats, err = componentList[uint8](v); err != nil {
+			return nil, fmt.Errorf("invalid ecformats: %w", err)
+		}
+	}
+	if v, ok := m.Arg("sni"); ok {
+		c.SNI, _ = v.(string)
+	}
+	return dactyloscopy.FromComponents(c)
+}
+
+func list16(m spoe.Message, name string) ([]uint16, error) {
+	v, ok := m.Arg(name)
+	if !ok {
+		return nil, nil
+	}
+	list, err := componentList[uint16](v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return list, nil
+}
+
+// componentList accepts the binary form sent by the ssl_fc_*_bin fetches, or a
+// string of decimal values.  A missing sample is sent as null, and treated as
+// not known
+func componentList[T uint8 | uint16](v any) ([]T, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return dactyloscopy.DecodeComponentList[T](v)
+	case string:
+		return dactyloscopy.ParseComponentList[T](v)
+	default:
+		return nil, fmt.Errorf("unexpected type %T", v)
+	}
+}
+
+func asInt(v any) (uint64, bool) {
+	switch v := v.(type) {
+	case int32:
+		return uint64(v), v >= 0 // #nosec G115 -- checked
+	case uint32:
+		return uint64(v), true
+	case int64:
+		return uint64(v), v >= 0 // #nosec G115 -- checked
+	case uint64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,87 @@
+// Command dactylospoe is an HAProxy SPOE agent which fingerprints TLS clients
+// from the ClientHello details HAProxy captures, and looks them up in a
+// fingerprint DB.  The results are set as HAProxy variables, so they can be
+// used in ACLs and routing.
+//
+// HAProxy needs to capture the ClientHello, and to send a message to the agent
+// when the session starts:
+//
+//	global
+//	    tune.ssl.capture-buffer-size 128
+//
+//	frontend https
+//	    bind :443 ssl crt /etc/haproxy/site.pem
+//	    filter spoe engine dactyloscopy config /etc/haproxy/dactyloscopy.conf
+//	    http-request deny if { var(sess.fp.fp_tags) -m sub bad }
+//
+//	backend dactyloscopy-agents
+//	    mode tcp
+//	    server agent 127.0.0.1:12345
+//
+// with /etc/haproxy/dactyloscopy.conf containing:
+//
+//	[dactyloscopy]
+//	spoe-agent dactyloscopy-agent
+//	    messages dactyloscopy
+//	    option var-prefix fp
+//	    timeout hello 2s
+//	    timeout idle 2m
+//	    timeout processing 100ms
+//	    use-backend dactyloscopy-agents
+//
+//	spoe-message dactyloscopy
+//	    args version=ssl_fc_protocol_hello_id ciphers=ssl_fc_cipherlist_bin(0) extensions=ssl_fc_extlist_bin(0) groups=ssl_fc_eclist_bin(0) ecformats=ssl_fc_ecformats_bin sni=ssl_fc_sni
+//	    event on-client-session
+//
+// The optional sigalgs and versions arguments take ssl_fc_sigalgs_bin and
+// ssl_fc_supported_versions_bin.  Lists may also be given as strings of
+// decimal values.  The agent sets ja3, ja3n and fp_known, plus fp_name,
+// fp_matched_on and fp_tags (comma separated) when the fingerprint is in the
+// DB, or fp_error if the fingerprint couldn't be calculated.  ja4 is only set
+// for clients which don't send ALPN, as HAProxy can't capture the protocols.
+//
+// The DB is a JSON array of objects with name, ja3, ja3n, ja4 and tags fields.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/spoe"
+)
+
+var scopes = map[string]spoe.Scope{
+	"proc": spoe.ScopeProcess,
+	"sess": spoe.ScopeSession,
+	"txn":  spoe.ScopeTransaction,
+	"req":  spoe.ScopeRequest,
+	"res":  spoe.ScopeResponse,
+}
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:12345", "address to listen for HAProxy on")
+	dbPath := flag.String("db", "", "JSON fingerprint DB")
+	message := flag.String("message", "dactyloscopy", "name of the spoe-message to handle")
+	scopeName := flag.String("scope", "sess", "scope of the variables set (proc, sess, txn, req or res)")
+	flag.Parse()
+
+	scope, ok := scopes[*scopeName]
+	if !ok {
+		log.Fatalf("unknown scope %q", *scopeName)
+	}
+
+	db := dactyloscopy.NewFingerprintDB(nil)
+	if *dbPath != "" {
+		var err error
+		if db, err = dactyloscopy.LoadFingerprintDBFile(*dbPath); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("loaded %d fingerprints from %s", len(db.Entries), *dbPath)
+	}
+
+	h := &fingerprinter{message: *message, scope: scope, db: db}
+	agent := &spoe.Agent{Handler: h.handle}
+	log.Printf("listening on %s", *listen)
+	log.Fatal(agent.ListenAndServe(*listen))
+}
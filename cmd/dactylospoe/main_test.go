@@ -0,0 +1,124 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/spoe"
+)
+
+func bin16(values ...uint16) []byte {
+	var out []byte
+	for _, v := range values {
+		out = append(out, byte(v>>8), byte(v))
+	}
+	return out
+}
+
+// notify plays the part of HAProxy, sending a hello and a single NOTIFY frame
+// to the agent and returning the variables it set
+func notify(t *testing.T, h *fingerprinter, messages ...spoe.Message) map[string]any {
+	t.Helper()
+	client, server := net.Pipe()
+	defer client.Close() // nolint:errcheck
+	go func() {
+		_ = (&spoe.Agent{Handler: h.handle}).ServeConn(server)
+	}()
+
+	hello, err := spoe.AppendKVList(nil, []spoe.KV{
+		{Name: "supported-versions", Value: "2.0"},
+		{Name: "max-frame-size", Value: uint32(16380)},
+		{Name: "capabilities", Value: "pipelining,async"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, spoe.WriteFrame(client, &spoe.Frame{Type: spoe.FrameHAProxyHello, Flags: spoe.FlagFin, Payload: hello}))
+	reply, err := spoe.ReadFrame(client, spoe.DefaultMaxFrameSize)
+	require.NoError(t, err)
+	require.Equal(t, spoe.FrameAgentHello, reply.Type)
+
+	payload, err := spoe.AppendMessages(nil, messages)
+	require.NoError(t, err)
+	require.NoError(t, spoe.WriteFrame(client, &spoe.Frame{Type: spoe.FrameNotify, Flags: spoe.FlagFin, StreamID: 1, FrameID: 1, Payload: payload}))
+	ack, err := spoe.ReadFrame(client, spoe.DefaultMaxFrameSize)
+	require.NoError(t, err)
+	require.Equal(t, spoe.FrameAck, ack.Type)
+
+	actions, err := spoe.ParseActions(ack.Payload)
+	require.NoError(t, err)
+	vars := map[string]any{}
+	for _, a := range actions {
+		assert.Equal(t, spoe.ScopeSession, a.Scope)
+		vars[a.Name] = a.Value
+	}
+	return vars
+}
+
+func TestAgentFingerprint(t *testing.T) {
+	// The same hello as a JA3 string, to get the expected hashes
+	expected, err := dactyloscopy.ParseJA3String("771,4865-4866-4867-49195,0-23-65281-10-11-16-13-43-51,29-23-24,0")
+	require.NoError(t, err)
+
+	h := &fingerprinter{
+		message: "dactyloscopy",
+		scope:   spoe.ScopeSession,
+		db: dactyloscopy.NewFingerprintDB([]dactyloscopy.DBEntry{
+			{Name: "Test client", JA3N: expected.JA3N, Tags: []string{"browser", "test"}},
+		}),
+	}
+
+	vars := notify(t, h,
+		spoe.Message{Name: "other", Args: []spoe.KV{{Name: "x", Value: "ignored"}}},
+		spoe.Message{Name: "dactyloscopy", Args: []spoe.KV{
+			{Name: "version", Value: int64(dactyloscopy.VersionTLS12)},
+			// HAProxy includes GREASE unless told not to
+			{Name: "ciphers", Value: bin16(0x2a2a, 0x1301, 0x1302, 0x1303, 0xc02b)},
+			{Name: "extensions", Value: bin16(0, 23, 65281, 10, 11, 16, 13, 43, 51)},
+			{Name: "groups", Value: bin16(29, 23, 24)},
+			{Name: "ecformats", Value: []byte{0}},
+			{Name: "sni", Value: "example.com"},
+		}},
+	)
+
+	assert.Equal(t, expected.JA3, vars["ja3"])
+	assert.Equal(t, expected.JA3N, vars["ja3n"])
+	// The ALPN extension was sent, but HAProxy can't give us its contents
+	assert.NotContains(t, vars, "ja4")
+	assert.Equal(t, true, vars["fp_known"])
+	assert.Equal(t, "Test client", vars["fp_name"])
+	assert.Equal(t, "ja3n", vars["fp_matched_on"])
+	assert.Equal(t, "browser,test", vars["fp_tags"])
+}
+
+func TestAgentFingerprintUnknown(t *testing.T) {
+	h := &fingerprinter{message: "dactyloscopy", scope: spoe.ScopeSession, db: dactyloscopy.NewFingerprintDB(nil)}
+	vars := notify(t, h, spoe.Message{Name: "dactyloscopy", Args: []spoe.KV{
+		{Name: "version", Value: int32(dactyloscopy.VersionTLS12)},
+		{Name: "ciphers", Value: "4865-4866"},
+		{Name: "extensions", Value: "0-10-11"},
+		{Name: "groups", Value: "29"},
+		{Name: "ecformats", Value: "0"},
+	}})
+	assert.NotEmpty(t, vars["ja3"])
+	assert.NotEmpty(t, vars["ja4"])
+	assert.Equal(t, false, vars["fp_known"])
+	assert.NotContains(t, vars, "fp_name")
+}
+
+func TestAgentFingerprintError(t *testing.T) {
+	h := &fingerprinter{message: "dactyloscopy", scope: spoe.ScopeSession}
+	vars := notify(t, h, spoe.Message{Name: "dactyloscopy", Args: []spoe.KV{
+		{Name: "ciphers", Value: []byte{1, 2, 3}},
+	}})
+	assert.Contains(t, vars, "fp_error")
+	assert.NotContains(t, vars, "ja3")
+
+	// No cipher list at all, e.g. capture-buffer-size isn't set
+	vars = notify(t, h, spoe.Message{Name: "dactyloscopy", Args: []spoe.KV{
+		{Name: "ciphers", Value: nil},
+	}})
+	assert.Contains(t, vars, "fp_error")
+}
@@ -0,0 +1,183 @@
+package dactyloscopy
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ComponentSet holds the parts of a ClientHello which have already been
+// extracted by something else, for example HAProxy's ssl_fc_cipherlist_bin,
+// ssl_fc_extlist_bin, ssl_fc_eclist_bin and ssl_fc_ecformats_bin fetches.  A nil
+// list means the component wasn't available, whereas an empty (non-nil) list
+// means it was available and empty.  DecodeComponentList and
+// ParseComponentList convert from the binary and decimal forms respectively.
+type ComponentSet struct {
+	// TLSVersion is the legacy version from the ClientHello, 0 if not known
+	TLSVersion          uint16
+	Ciphersuites        []uint16
+	Extensions          []uint16
+	SupportedGroups     []uint16
+	ECPointFormats      []uint8
+	SignatureAlgorithms []uint16
+	SupportedVersions   []uint16
+	KeyShareGroups      []uint16
+	ALPN                []string
+	SNI                 string
+}
+
+// FromComponents builds a fingerprint from pre-extracted components, giving the
+// same fingerprint as ProcessClientHello would for the same hello.  Components
+// which weren't supplied are listed in Unknown, and a hash is only calculated
+// when every component it covers is known.
+func FromComponents(c ComponentSet) (*Fingerprint, error) {
+	if c.Ciphersuites == nil {
+		return nil, fmt.Errorf("ciphersuites are required to build a fingerprint")
+	}
+
+	f := &Fingerprint{
+		MessageType:       HandshakeType,
+		TLSVersion:        c.TLSVersion,
+		ECurves:           c.SupportedGroups,
+		EcPointFmt:        c.ECPointFormats,
+		SigAlg:            c.SignatureAlgorithms,
+		SupportedVersions: c.SupportedVersions,
+		KeyShareGroups:    c.KeyShareGroups,
+		ALPNProtocols:     c.ALPN,
+		SNI:               c.SNI,
+		Unknown:           []string{ComponentCompression},
+	}
+
+	// Match the ClientHello parser, which drops GREASE suites and notes them
+	for _, suite := range c.Ciphersuites {
+		if isGrease(suite) {
+			f.Grease = true
+			continue
+		}
+		f.Ciphersuite = append(f.Ciphersuite, suite)
+	}
+
+	// ...and leaves padding out of the extension list
+	if c.Extensions != nil {
+		f.Extensions = make([]uint16, 0, len(c.Extensions))
+		for _, ext := range c.Extensions {
+			if ext == ExtPadding {
+				f.Padding = true
+				continue
+			}
+			f.Extensions = append(f.Extensions, ext)
+		}
+	}
+
+	// A component is known if it was given, or if the extension list is known
+	// and the extension which carries it wasn't sent
+	sent := func(ext uint16) bool {
+		return c.Extensions == nil || slices.Contains(c.Extensions, ext)
+	}
+	for _, component := range []struct {
+		name    string
+		given   bool
+		carrier uint16
+	}{
+		{ComponentSupportedGroups, c.SupportedGroups != nil, ExtEllipticCurves},
+		{ComponentECPointFormats, c.ECPointFormats != nil, ExtECPointFormats},
+		{ComponentSignatureAlgorithms, c.SignatureAlgorithms != nil, ExtSignatureAlgorithms},
+		{ComponentSupportedVersions, c.SupportedVersions != nil, ExtSupportedVersions},
+		{ComponentKeyShares, c.KeyShareGroups != nil, 0x0033},
+		{ComponentALPN, c.ALPN != nil, ExtALPN},
+		{ComponentSNI, c.SNI != "", ExtServerName},
+	} {
+		if !component.given && sent(component.carrier) {
+			f.Unknown = append(f.Unknown, component.name)
+		}
+	}
+	if c.TLSVersion == 0 {
+		f.Unknown = append(f.Unknown, ComponentTLSVersion)
+	}
+	if c.Extensions == nil {
+		f.Unknown = append(f.Unknown, ComponentExtensions, ComponentExtensionOrder)
+	}
+
+	// The parser uses a 0 point format when there are none, as the JA3
+	// reference implementation does
+	if f.Known(ComponentECPointFormats) && len(f.EcPointFmt) == 0 {
+		f.EcPointFmt = []uint8{0}
+	}
+
+	if f.knownAll(ComponentTLSVersion, ComponentExtensions, ComponentSupportedGroups, ComponentECPointFormats) {
+		if err := f.generateJA3(); err != nil {
+			return nil, fmt.Errorf("error generating JA3: %w", err)
+		}
+		if err := f.generateJA3N(); err != nil {
+			return nil, fmt.Errorf("error generating JA3N: %w", err)
+		}
+	}
+	if f.knownAll(ComponentTLSVersion, ComponentExtensions, ComponentALPN) {
+		if err := f.generateJA4(); err != nil {
+			return nil, fmt.Errorf("error generating JA4: %w", err)
+		}
+	}
+	return f, nil
+}
+
+func (f *Fingerprint) knownAll(components ...string) bool {
+	for _, component := range components {
+		if !f.Known(component) {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeComponentList decodes a list of big endian values, as found in the
+// ClientHello itself and returned by HAProxy's ssl_fc_*_bin fetches.  Use
+// uint16 for ciphersuites, extensions and groups, and uint8 for point formats.
+// An empty input gives an empty, non-nil, list
+func DecodeComponentList[T uint8 | uint16](b []byte) ([]T, error) {
+	var zero T
+	size := 2
+	if _, ok := any(zero).(uint8); ok {
+		size = 1
+	}
+	if len(b)%size != 0 {
+		return nil, fmt.Errorf("list of %d bytes isn't a multiple of %d", len(b), size)
+	}
+	out := make([]T, 0, len(b)/size)
+	for i := 0; i < len(b); i += size {
+		if size == 1 {
+			out = append(out, T(b[i]))
+		} else {
+			out = append(out, T(uint16(b[i])<<8|uint16(b[i+1])))
+		}
+	}
+	return out, nil
+}
+
+// ParseComponentList parses a list of decimal values separated by dashes (as
+// in JA3), commas, colons or spaces.  A value may instead be given in hex with
+// a 0x prefix.  An empty input gives an empty, non-nil, list
+func ParseComponentList[T uint8 | uint16](s string) ([]T, error) {
+	var zero T
+	bits := 16
+	if _, ok := any(zero).(uint8); ok {
+		bits = 8
+	}
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '-' || r == ',' || r == ':' || r == ' '
+	})
+	out := make([]T, 0, len(fields))
+	for _, field := range fields {
+		// Base 0 would treat a leading 0 as octal, so only accept 0x
+		base := 10
+		if strings.HasPrefix(field, "0x") || strings.HasPrefix(field, "0X") {
+			field, base = field[2:], 16
+		}
+		v, err := strconv.ParseUint(field, base, bits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", field, err)
+		}
+		out = append(out, T(v))
+	}
+	return out, nil
+}
@@ -0,0 +1,239 @@
+package dactyloscopy_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+func encodeList16(values []uint16) []byte {
+	out := make([]byte, 0, len(values)*2)
+	for _, v := range values {
+		out = append(out, byte(v>>8), byte(v))
+	}
+	return out
+}
+
+func TestFromComponents(t *testing.T) {
+	tests := []struct {
+		name        string
+		set         dactyloscopy.ComponentSet
+		wantErr     bool
+		wantJA3     string
+		wantJA4     bool
+		wantSuites  []uint16
+		wantExts    []uint16
+		wantGrease  bool
+		wantPadding bool
+		known       []string
+		unknown     []string
+	}{
+		{
+			name: "JA3 components",
+			set: dactyloscopy.ComponentSet{
+				TLSVersion:      dactyloscopy.VersionTLS12,
+				Ciphersuites:    []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+				Extensions:      []uint16{0, 10, 11, 13},
+				SupportedGroups: []uint16{0x001d, 0x0017},
+				ECPointFormats:  []uint8{},
+			},
+			// An empty point format list is hashed as 0, as the parser does
+			wantJA3:    "9c752f884a84db93da953e5a46fb19af",
+			wantJA4:    true,
+			wantSuites: []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+			wantExts:   []uint16{0, 10, 11, 13},
+			// ALPN wasn't sent so is known to be empty, the server name was
+			known:   []string{dactyloscopy.ComponentALPN, dactyloscopy.ComponentExtensions},
+			unknown: []string{dactyloscopy.ComponentSNI, dactyloscopy.ComponentSignatureAlgorithms},
+		},
+		{
+			name: "GREASE and padding",
+			set: dactyloscopy.ComponentSet{
+				TLSVersion:   dactyloscopy.VersionTLS12,
+				Ciphersuites: []uint16{0x1a1a, 0x1301},
+				Extensions:   []uint16{0x2a2a, 10, 43, dactyloscopy.ExtPadding},
+			},
+			wantJA4:     true,
+			wantSuites:  []uint16{0x1301},
+			wantExts:    []uint16{0x2a2a, 10, 43},
+			wantGrease:  true,
+			wantPadding: true,
+			unknown:     []string{dactyloscopy.ComponentSupportedGroups, dactyloscopy.ComponentSupportedVersions},
+		},
+		{
+			name: "no extensions",
+			set: dactyloscopy.ComponentSet{
+				TLSVersion:   dactyloscopy.VersionTLS12,
+				Ciphersuites: []uint16{0x1301},
+			},
+			wantSuites: []uint16{0x1301},
+			unknown:    []string{dactyloscopy.ComponentExtensions, dactyloscopy.ComponentALPN},
+		},
+		{
+			name:    "no ciphersuites",
+			set:     dactyloscopy.ComponentSet{TLSVersion: dactyloscopy.VersionTLS12},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := dactyloscopy.FromComponents(tt.set)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJA3, f.JA3)
+			assert.Equal(t, tt.wantJA4, f.JA4 != "")
+			assert.Equal(t, tt.wantSuites, f.Ciphersuite)
+			assert.Equal(t, tt.wantExts, f.Extensions)
+			assert.Equal(t, tt.wantGrease, f.Grease)
+			assert.Equal(t, tt.wantPadding, f.Padding)
+			for _, c := range tt.known {
+				assert.True(t, f.Known(c), c)
+			}
+			for _, c := range tt.unknown {
+				assert.False(t, f.Known(c), c)
+			}
+		})
+	}
+}
+
+func TestFromComponentsMatchesParse(t *testing.T) {
+	chrome := &dactyloscopy.Fingerprint{
+		TLSVersion:        dactyloscopy.VersionTLS12,
+		Grease:            true,
+		Ciphersuite:       []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+		Extensions:        []uint16{0x2a2a, 0, 10, 11, 13, 16, 27, 43, 51},
+		ECurves:           []uint16{0x2a2a, 0x11ec, 0x001d, 0x0017},
+		SigAlg:            []uint16{0x0403, 0x0804},
+		SupportedVersions: []uint16{0x0304, 0x0303},
+		ALPNProtocols:     []string{"h2", "http/1.1"},
+		KeyShareGroups:    []uint16{0x2a2a, 0x11ec},
+		Compression:       []uint8{0},
+		EcPointFmt:        []uint8{0},
+		Padding:           true,
+		PaddingLen:        100,
+	}
+	chromeHello, err := chrome.MarshalClientHello()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		hello []byte
+	}{
+		{"crypto/tls", goClientHello(t, &tls.Config{ServerName: "example.com", NextProtos: []string{"h2"}})},
+		{"GREASE and padding", chromeHello},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := dactyloscopy.ProcessClientHello(tt.hello)
+			require.NoError(t, err)
+
+			// The binary forms, as HAProxy would give them, include GREASE
+			// suites and padding
+			suites := parsed.Ciphersuite
+			if parsed.Grease {
+				suites = append([]uint16{0x1a1a}, suites...)
+			}
+			exts := parsed.Extensions
+			if parsed.Padding {
+				exts = append(exts[:len(exts):len(exts)], dactyloscopy.ExtPadding)
+			}
+			cipherList, err := dactyloscopy.DecodeComponentList[uint16](encodeList16(suites))
+			require.NoError(t, err)
+			extList, err := dactyloscopy.DecodeComponentList[uint16](encodeList16(exts))
+			require.NoError(t, err)
+			groupList, err := dactyloscopy.DecodeComponentList[uint16](encodeList16(parsed.ECurves))
+			require.NoError(t, err)
+			formats, err := dactyloscopy.DecodeComponentList[uint8](parsed.EcPointFmt)
+			require.NoError(t, err)
+
+			f, err := dactyloscopy.FromComponents(dactyloscopy.ComponentSet{
+				TLSVersion:      parsed.TLSVersion,
+				Ciphersuites:    cipherList,
+				Extensions:      extList,
+				SupportedGroups: groupList,
+				ECPointFormats:  formats,
+				ALPN:            parsed.ALPNProtocols,
+				SNI:             parsed.SNI,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, parsed.JA3, f.JA3)
+			assert.Equal(t, parsed.JA3N, f.JA3N)
+			assert.Equal(t, parsed.JA4, f.JA4)
+			assert.Equal(t, parsed.Grease, f.Grease)
+			assert.Equal(t, parsed.Padding, f.Padding)
+		})
+	}
+}
+
+func TestDecodeComponentList(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		want    []uint16
+		wantErr bool
+	}{
+		{"empty", []byte{}, []uint16{}, false},
+		{"two values", []byte{0x13, 0x01, 0xc0, 0x2b}, []uint16{0x1301, 0xc02b}, false},
+		{"odd length", []byte{1, 2, 3}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dactyloscopy.DecodeComponentList[uint16](tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseComponentList(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []uint16
+		wantErr bool
+	}{
+		{"empty", "", []uint16{}, false},
+		{"JA3 dashes", "4865-4866-49195", []uint16{0x1301, 0x1302, 0xc02b}, false},
+		{"commas and spaces", "0, 10, 11", []uint16{0, 10, 11}, false},
+		{"hex", "0x001d:23", []uint16{0x001d, 0x0017}, false},
+		{"leading zero is decimal", "010", []uint16{10}, false},
+		{"not a number", "1-x", nil, true},
+		{"out of range", "65536", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dactyloscopy.ParseComponentList[uint16](tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseComponentListUint8(t *testing.T) {
+	got, err := dactyloscopy.ParseComponentList[uint8]("0-1-2")
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{0, 1, 2}, got)
+
+	_, err = dactyloscopy.ParseComponentList[uint8]("1-300")
+	assert.Error(t, err)
+}
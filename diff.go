@@ -0,0 +1,218 @@
+package dactyloscopy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ComponentDiff describes how one component of a fingerprint (e.g. the
+// ciphersuites) differs between two fingerprints.  Values are rendered with
+// their IANA names, and GREASE values are collapsed to "GREASE" as they are
+// randomised by the client and would otherwise always differ
+type ComponentDiff struct {
+	Component string   `json:"component"`
+	Added     []string `json:"added,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+	// Reordered is set when the values common to both fingerprints appear in
+	// a different order
+	Reordered bool `json:"reordered,omitempty"`
+	// Unknown is set when either fingerprint didn't carry this component, so
+	// it couldn't be compared
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// Changed reports whether there is any difference in this component
+func (c ComponentDiff) Changed() bool {
+	return len(c.Added) > 0 || len(c.Removed) > 0 || c.Reordered
+}
+
+// FingerprintDiff is the result of comparing two fingerprints, A and B.  Added
+// values are those present in B but not A, and Removed the reverse
+type FingerprintDiff struct {
+	Components []ComponentDiff `json:"components"`
+	// Identical is set when there are no differences in any component
+	Identical bool `json:"identical"`
+	// OrderOnly is set when both fingerprints contain exactly the same values,
+	// but at least one component is ordered differently
+	OrderOnly bool `json:"order_only"`
+}
+
+// Changed returns only the components which differ
+func (d FingerprintDiff) Changed() []ComponentDiff {
+	var changed []ComponentDiff
+	for _, c := range d.Components {
+		if c.Changed() {
+			changed = append(changed, c)
+		}
+	}
+	return changed
+}
+
+// Diff compares two fingerprints component by component
+func Diff(a, b *Fingerprint) FingerprintDiff {
+	d := FingerprintDiff{
+		Components: []ComponentDiff{
+			diffComponent(ComponentTLSVersion, diffValues([]uint16{a.TLSVersion}, GetIANAVersion), diffValues([]uint16{b.TLSVersion}, GetIANAVersion)),
+			diffComponent(ComponentCiphersuites, diffValues(a.Ciphersuite, GetIANACiphersuite), diffValues(b.Ciphersuite, GetIANACiphersuite)),
+			diffComponent(ComponentExtensions, diffValues(a.Extensions, extensionName), diffValues(b.Extensions, extensionName)),
+			diffComponent(ComponentSupportedGroups, diffValues(a.ECurves, GetIANAGroup), diffValues(b.ECurves, GetIANAGroup)),
+			diffComponent(ComponentECPointFormats, diffValues8(a.EcPointFmt, LookupECPointFormat), diffValues8(b.EcPointFmt, LookupECPointFormat)),
+			diffComponent(ComponentSignatureAlgorithms, diffValues(a.SigAlg, GetIANASignatureScheme), diffValues(b.SigAlg, GetIANASignatureScheme)),
+			diffComponent(ComponentSupportedVersions, diffValues(a.SupportedVersions, GetIANAVersion), diffValues(b.SupportedVersions, GetIANAVersion)),
+			diffComponent(ComponentALPN, a.ALPNProtocols, b.ALPNProtocols),
+			diffComponent(ComponentKeyShares, diffValues(a.KeyShareGroups, GetIANAGroup), diffValues(b.KeyShareGroups, GetIANAGroup)),
+			diffComponent(ComponentCompression, diffValues8(a.Compression, LookupCompressionMethod), diffValues8(b.Compression, LookupCompressionMethod)),
+		},
+	}
+
+	// Partial fingerprints (e.g. from a JA3 string) can't be compared on the
+	// components they don't carry, or on order when it was sorted away
+	for i, c := range d.Components {
+		if !a.Known(c.Component) || !b.Known(c.Component) {
+			d.Components[i] = ComponentDiff{Component: c.Component, Unknown: true}
+		}
+	}
+	for _, unordered := range [][2]string{
+		{ComponentCiphersuites, ComponentCiphersuiteOrder},
+		{ComponentExtensions, ComponentExtensionOrder},
+	} {
+		if !a.Known(unordered[1]) || !b.Known(unordered[1]) {
+			for i := range d.Components {
+				if d.Components[i].Component == unordered[0] {
+					d.Components[i].Reordered = false
+				}
+			}
+		}
+	}
+
+	d.Identical = true
+	d.OrderOnly = true
+	for _, c := range d.Components {
+		if c.Changed() {
+			d.Identical = false
+		}
+		if len(c.Added) > 0 || len(c.Removed) > 0 {
+			d.OrderOnly = false
+		}
+	}
+	if d.Identical {
+		d.OrderOnly = false
+	}
+	return d
+}
+
+func diffValues(values []uint16, name func(uint16) string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if isGrease(v) {
+			out = append(out, "GREASE")
+			continue
+		}
+		out = append(out, name(v))
+	}
+	return out
+}
+
+func diffValues8(values []uint8, lookup func(uint8) (IanaEntry, bool)) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if entry, ok := lookup(v); ok {
+			out = append(out, entry.Name)
+		} else {
+			out = append(out, fmt.Sprintf("%d", v))
+		}
+	}
+	return out
+}
+
+func diffComponent(name string, a, b []string) ComponentDiff {
+	c := ComponentDiff{Component: name}
+
+	inA := countValues(a)
+	inB := countValues(b)
+	for _, v := range b {
+		if inA[v] == 0 {
+			c.Added = append(c.Added, v)
+		} else {
+			inA[v]--
+		}
+	}
+	for _, v := range a {
+		if inB[v] == 0 {
+			c.Removed = append(c.Removed, v)
+		} else {
+			inB[v]--
+		}
+	}
+
+	// Compare the order of the values common to both
+	commonA := commonValues(a, b)
+	commonB := commonValues(b, a)
+	for i := range commonA {
+		if commonA[i] != commonB[i] {
+			c.Reordered = true
+			break
+		}
+	}
+	return c
+}
+
+func countValues(values []string) map[string]int {
+	counts := make(map[string]int, len(values))
+	for _, v := range values {
+		counts[v]++
+	}
+	return counts
+}
+
+// commonValues returns the values of a which also appear in b, in the order
+// they appear in a
+func commonValues(a, b []string) []string {
+	available := countValues(b)
+	var out []string
+	for _, v := range a {
+		if available[v] > 0 {
+			available[v]--
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// WriteText renders a human readable summary of the differences
+func (d FingerprintDiff) WriteText(w io.Writer) error {
+	switch {
+	case d.Identical:
+		_, err := fmt.Fprintln(w, "fingerprints are identical")
+		return err
+	case d.OrderOnly:
+		if _, err := fmt.Fprintln(w, "fingerprints differ in ordering only"); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range d.Changed() {
+		var parts []string
+		if len(c.Added) > 0 {
+			parts = append(parts, fmt.Sprintf("%d added (%s)", len(c.Added), strings.Join(c.Added, ", ")))
+		}
+		if len(c.Removed) > 0 {
+			parts = append(parts, fmt.Sprintf("%d removed (%s)", len(c.Removed), strings.Join(c.Removed, ", ")))
+		}
+		if c.Reordered {
+			parts = append(parts, "reordered")
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", c.Component, strings.Join(parts, "; ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the text rendering of the diff
+func (d FingerprintDiff) String() string {
+	var b strings.Builder
+	_ = d.WriteText(&b)
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package dactyloscopy_test
+
+import (
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	base := &dactyloscopy.Fingerprint{
+		TLSVersion:    dactyloscopy.VersionTLS12,
+		Ciphersuite:   []uint16{0x1301, 0xc02b},
+		Extensions:    []uint16{0x2a2a, 0, 16, 27, 43},
+		ECurves:       []uint16{0x2a2a, 0x001d},
+		EcPointFmt:    []uint8{0},
+		ALPNProtocols: []string{"h2", "http/1.1"},
+	}
+
+	tests := []struct {
+		name          string
+		b             *dactyloscopy.Fingerprint
+		wantIdentical bool
+		wantOrderOnly bool
+		wantChanged   []string
+		wantText      string
+	}{
+		{
+			// GREASE differs per connection, and shouldn't count
+			name: "identical apart from GREASE",
+			b: &dactyloscopy.Fingerprint{
+				TLSVersion:    dactyloscopy.VersionTLS12,
+				Ciphersuite:   []uint16{0x1301, 0xc02b},
+				Extensions:    []uint16{0xdada, 0, 16, 27, 43},
+				ECurves:       []uint16{0xdada, 0x001d},
+				EcPointFmt:    []uint8{0},
+				ALPNProtocols: []string{"h2", "http/1.1"},
+			},
+			wantIdentical: true,
+			wantText:      "fingerprints are identical\n",
+		},
+		{
+			name: "extensions reordered",
+			b: &dactyloscopy.Fingerprint{
+				TLSVersion:    dactyloscopy.VersionTLS12,
+				Ciphersuite:   []uint16{0x1301, 0xc02b},
+				Extensions:    []uint16{0x2a2a, 43, 27, 16, 0},
+				ECurves:       []uint16{0x2a2a, 0x001d},
+				EcPointFmt:    []uint8{0},
+				ALPNProtocols: []string{"h2", "http/1.1"},
+			},
+			wantOrderOnly: true,
+			wantChanged:   []string{dactyloscopy.ComponentExtensions},
+			wantText:      "fingerprints differ in ordering only\nextensions: reordered\n",
+		},
+		{
+			name: "added and removed",
+			b: &dactyloscopy.Fingerprint{
+				TLSVersion:    dactyloscopy.VersionTLS12,
+				Ciphersuite:   []uint16{0x1301, 0xc02b},
+				Extensions:    []uint16{0x2a2a, 0, 16, 43, 17613},
+				ECurves:       []uint16{0x2a2a, 0x001d},
+				EcPointFmt:    []uint8{0},
+				ALPNProtocols: []string{"http/1.1"},
+			},
+			wantChanged: []string{dactyloscopy.ComponentExtensions, dactyloscopy.ComponentALPN},
+			wantText: "extensions: 1 added (application_settings); 1 removed (compress_certificate)\n" +
+				"alpn: 1 removed (h2)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := dactyloscopy.Diff(base, tt.b)
+			assert.Equal(t, tt.wantIdentical, d.Identical)
+			assert.Equal(t, tt.wantOrderOnly, d.OrderOnly)
+			var changed []string
+			for _, c := range d.Changed() {
+				changed = append(changed, c.Component)
+			}
+			assert.Equal(t, tt.wantChanged, changed)
+			assert.Equal(t, tt.wantText, d.String())
+		})
+	}
+}
@@ -0,0 +1,547 @@
+package dactyloscopy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// HexBytes is a byte slice which is rendered as hex when marshalled to JSON
+type HexBytes []byte
+
+// MarshalJSON renders the bytes as a hex string
+func (h HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(h))
+}
+
+// DissectNode is a single field of a dissected client hello.  Offset and Length
+// are relative to the start of the buffer passed to Dissect, Raw is only set
+// on leaf nodes so that large structures aren't repeated at every level
+type DissectNode struct {
+	Name     string         `json:"name"`
+	Value    string         `json:"value,omitempty"`
+	Offset   int            `json:"offset"`
+	Length   int            `json:"length"`
+	Raw      HexBytes       `json:"raw,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	Children []*DissectNode `json:"children,omitempty"`
+}
+
+// Dissection is an annotated breakdown of a client hello, useful for working
+// out why a hello failed to parse, or why it looks strange
+type Dissection struct {
+	Fields []*DissectNode `json:"fields"`
+	// Complete is false if the dissection stopped early due to an error, in
+	// which case Fields contains everything parsed up to that point
+	Complete bool `json:"complete"`
+}
+
+// Dissect breaks down a client hello record into a tree of fields with byte
+// offsets, lengths, raw values and IANA names.  If an error is encountered the
+// partial dissection is returned alongside it.
+func Dissect(buf []byte) (*Dissection, error) {
+	d := &Dissection{}
+	c := &dissectCursor{buf: buf}
+
+	record := &DissectNode{Name: "TLS Record Layer", Offset: 0}
+	d.Fields = append(d.Fields, record)
+
+	err := dissectRecord(c, record)
+	record.Length = c.pos
+	if err != nil {
+		return d, err
+	}
+
+	if c.pos < len(buf) {
+		d.Fields = append(d.Fields, &DissectNode{
+			Name:   "Trailing Data",
+			Offset: c.pos,
+			Length: len(buf) - c.pos,
+			Raw:    HexBytes(buf[c.pos:]),
+		})
+	}
+	d.Complete = true
+	return d, nil
+}
+
+// dissectCursor tracks our position within the original buffer so that
+// offsets can be reported, end marks the limit of the current structure
+type dissectCursor struct {
+	buf []byte
+	pos int
+	end int
+}
+
+func (c *dissectCursor) limit() int {
+	if c.end == 0 || c.end > len(c.buf) {
+		return len(c.buf)
+	}
+	return c.end
+}
+
+func (c *dissectCursor) remaining() int {
+	return c.limit() - c.pos
+}
+
+// field reads a fixed size leaf field and attaches it to parent.  On a short
+// read the node is still attached, marked with the error, so the tree shows
+// exactly where things went wrong
+func (c *dissectCursor) field(parent *DissectNode, name string, size int) (*DissectNode, []byte, error) {
+	node := &DissectNode{Name: name, Offset: c.pos, Length: size}
+	parent.Children = append(parent.Children, node)
+	if c.remaining() < size {
+		node.Length = c.remaining()
+		node.Raw = HexBytes(c.buf[c.pos:c.limit()])
+		err := fmt.Errorf("%s truncated at offset %d, need %d bytes, have %d", name, c.pos, size, c.remaining())
+		node.Error = err.Error()
+		c.pos = c.limit()
+		return node, nil, err
+	}
+	raw := c.buf[c.pos : c.pos+size]
+	node.Raw = HexBytes(raw)
+	c.pos += size
+	return node, raw, nil
+}
+
+func (c *dissectCursor) uint8Field(parent *DissectNode, name string) (*DissectNode, uint8, error) {
+	node, raw, err := c.field(parent, name, 1)
+	if err != nil {
+		return node, 0, err
+	}
+	node.Value = fmt.Sprintf("%d", raw[0])
+	return node, raw[0], nil
+}
+
+func (c *dissectCursor) uint16Field(parent *DissectNode, name string) (*DissectNode, uint16, error) {
+	node, raw, err := c.field(parent, name, 2)
+	if err != nil {
+		return node, 0, err
+	}
+	v := uint16(raw[0])<<8 | uint16(raw[1])
+	node.Value = fmt.Sprintf("%d", v)
+	return node, v, nil
+}
+
+func (c *dissectCursor) uint24Field(parent *DissectNode, name string) (*DissectNode, int, error) {
+	node, raw, err := c.field(parent, name, 3)
+	if err != nil {
+		return node, 0, err
+	}
+	v := int(raw[0])<<16 | int(raw[1])<<8 | int(raw[2])
+	node.Value = fmt.Sprintf("%d", v)
+	return node, v, nil
+}
+
+// container starts a structure of the given length, returning the node and a
+// function which restores the previous limit once the structure is complete.
+// A length running past the current limit is flagged, but we carry on as far
+// as the data allows
+func (c *dissectCursor) container(parent *DissectNode, name string, length int) (*DissectNode, func(), error) {
+	node := &DissectNode{Name: name, Offset: c.pos, Length: length}
+	parent.Children = append(parent.Children, node)
+	prevEnd := c.end
+	var err error
+	if length > c.remaining() {
+		err = fmt.Errorf("%s length %d runs past the available %d bytes", name, length, c.remaining())
+		node.Error = err.Error()
+		node.Length = c.remaining()
+		length = c.remaining()
+	}
+	c.end = c.pos + length
+	return node, func() {
+		c.pos = c.end
+		c.end = prevEnd
+	}, err
+}
+
+func dissectRecord(c *dissectCursor, record *DissectNode) error {
+	node, contentType, err := c.uint8Field(record, "Content Type")
+	if err != nil {
+		return err
+	}
+	if contentType == HandshakeType {
+		node.Value = fmt.Sprintf("Handshake (%d)", contentType)
+	}
+
+	node, version, err := c.uint16Field(record, "Version")
+	if err != nil {
+		return err
+	}
+	node.Value = versionValue(version)
+
+	_, length, err := c.uint16Field(record, "Length")
+	if err != nil {
+		return err
+	}
+	if contentType != HandshakeType {
+		return fmt.Errorf("content type %d is not a handshake", contentType)
+	}
+
+	handshake, done, lengthErr := c.container(record, "Handshake Protocol", int(length))
+	defer done()
+	if err := dissectHandshake(c, handshake); err != nil {
+		return err
+	}
+	// A record which claims to be longer than the data we have is most likely
+	// a hello fragmented over several TCP segments or records
+	return lengthErr
+}
+
+func dissectHandshake(c *dissectCursor, handshake *DissectNode) error {
+	node, msgType, err := c.uint8Field(handshake, "Handshake Type")
+	if err != nil {
+		return err
+	}
+	if msgType == ClientHelloMsg {
+		node.Value = fmt.Sprintf("Client Hello (%d)", msgType)
+		handshake.Value = "Client Hello"
+	} else {
+		return fmt.Errorf("handshake type %d is not a client hello", msgType)
+	}
+
+	_, length, err := c.uint24Field(handshake, "Length")
+	if err != nil {
+		return err
+	}
+
+	hello, done, lengthErr := c.container(handshake, "Client Hello", length)
+	defer done()
+	if err := dissectClientHello(c, hello); err != nil {
+		return err
+	}
+	return lengthErr
+}
+
+func dissectClientHello(c *dissectCursor, hello *DissectNode) error {
+	node, version, err := c.uint16Field(hello, "Version")
+	if err != nil {
+		return err
+	}
+	node.Value = versionValue(version)
+
+	node, _, err = c.field(hello, "Random", 32)
+	if err != nil {
+		return err
+	}
+	node.Value = hex.EncodeToString(node.Raw)
+
+	_, sessionIDLen, err := c.uint8Field(hello, "Session ID Length")
+	if err != nil {
+		return err
+	}
+	if sessionIDLen > 0 {
+		node, _, err = c.field(hello, "Session ID", int(sessionIDLen))
+		if err != nil {
+			return err
+		}
+		node.Value = hex.EncodeToString(node.Raw)
+	}
+
+	_, suitesLen, err := c.uint16Field(hello, "Cipher Suites Length")
+	if err != nil {
+		return err
+	}
+	if err := dissectList16(c, hello, "Cipher Suites", "Cipher Suite", int(suitesLen), ciphersuiteValue); err != nil {
+		return err
+	}
+
+	_, compLen, err := c.uint8Field(hello, "Compression Methods Length")
+	if err != nil {
+		return err
+	}
+	methods, done, err := c.container(hello, "Compression Methods", int(compLen))
+	if err != nil {
+		done()
+		return err
+	}
+	for c.remaining() > 0 {
+		node, method, err := c.uint8Field(methods, "Compression Method")
+		if err != nil {
+			done()
+			return err
+		}
+		node.Value = uint8Value(method, LookupCompressionMethod)
+	}
+	done()
+
+	if c.remaining() == 0 {
+		// Extensions are optional prior to TLS 1.3
+		return nil
+	}
+
+	_, extLen, err := c.uint16Field(hello, "Extensions Length")
+	if err != nil {
+		return err
+	}
+	extensions, done, lengthErr := c.container(hello, "Extensions", int(extLen))
+	defer done()
+	for c.remaining() > 0 {
+		if err := dissectExtension(c, extensions); err != nil {
+			return err
+		}
+	}
+	return lengthErr
+}
+
+func dissectExtension(c *dissectCursor, extensions *DissectNode) error {
+	ext := &DissectNode{Name: "Extension", Offset: c.pos}
+	extensions.Children = append(extensions.Children, ext)
+	start := c.pos
+	defer func() { ext.Length = c.pos - start }()
+
+	node, extType, err := c.uint16Field(ext, "Type")
+	if err != nil {
+		return err
+	}
+	ext.Value = extensionName(extType)
+	node.Value = fmt.Sprintf("%s (%d)", ext.Value, extType)
+
+	_, length, err := c.uint16Field(ext, "Length")
+	if err != nil {
+		return err
+	}
+
+	data, done, err := c.container(ext, "Data", int(length))
+	defer done()
+	if err != nil {
+		return err
+	}
+
+	switch extType {
+	case ExtServerName:
+		err = dissectServerName(c, data)
+	case ExtEllipticCurves:
+		err = dissectPrefixedList16(c, data, "Supported Groups", "Supported Group", groupValue)
+	case ExtSignatureAlgorithms, 0x0032: // signature_algorithms_cert
+		err = dissectPrefixedList16(c, data, "Signature Hash Algorithms", "Signature Algorithm", signatureSchemeValue)
+	case ExtECPointFormats:
+		err = dissectPrefixedList8(c, data, "EC Point Formats", "EC Point Format", LookupECPointFormat)
+	case 0x002d: // psk_key_exchange_modes
+		err = dissectPrefixedList8(c, data, "PSK Key Exchange Modes", "PSK Key Exchange Mode", LookupPSKKeyExchangeMode)
+	case ExtSupportedVersions:
+		var listLen uint8
+		if _, listLen, err = c.uint8Field(data, "Supported Versions Length"); err == nil {
+			err = dissectList16(c, data, "Supported Versions", "Supported Version", int(listLen), versionValue)
+		}
+	case ExtALPN:
+		err = dissectALPN(c, data)
+	case 0x0033: // key_share
+		err = dissectKeyShare(c, data)
+	default:
+		if length > 0 {
+			data.Raw = HexBytes(c.buf[c.pos:c.limit()])
+		}
+	}
+	return err
+}
+
+func dissectServerName(c *dissectCursor, data *DissectNode) error {
+	_, listLen, err := c.uint16Field(data, "Server Name List Length")
+	if err != nil {
+		return err
+	}
+	list, done, err := c.container(data, "Server Name List", int(listLen))
+	defer done()
+	if err != nil {
+		return err
+	}
+	for c.remaining() > 0 {
+		node, nameType, err := c.uint8Field(list, "Server Name Type")
+		if err != nil {
+			return err
+		}
+		if nameType == 0 {
+			node.Value = "host_name (0)"
+		}
+		_, nameLen, err := c.uint16Field(list, "Server Name Length")
+		if err != nil {
+			return err
+		}
+		node, raw, err := c.field(list, "Server Name", int(nameLen))
+		if err != nil {
+			return err
+		}
+		node.Value = string(raw)
+	}
+	return nil
+}
+
+func dissectALPN(c *dissectCursor, data *DissectNode) error {
+	_, listLen, err := c.uint16Field(data, "ALPN Extension Length")
+	if err != nil {
+		return err
+	}
+	list, done, err := c.container(data, "ALPN Protocols", int(listLen))
+	defer done()
+	if err != nil {
+		return err
+	}
+	for c.remaining() > 0 {
+		_, protoLen, err := c.uint8Field(list, "ALPN String Length")
+		if err != nil {
+			return err
+		}
+		node, raw, err := c.field(list, "ALPN Next Protocol", int(protoLen))
+		if err != nil {
+			return err
+		}
+		node.Value = string(raw)
+		if entry, ok := LookupALPN(string(raw)); ok {
+			node.Value = fmt.Sprintf("%s (%s)", raw, entry.Name)
+		}
+	}
+	return nil
+}
+
+func dissectKeyShare(c *dissectCursor, data *DissectNode) error {
+	_, listLen, err := c.uint16Field(data, "Client Key Share Length")
+	if err != nil {
+		return err
+	}
+	list, done, err := c.container(data, "Key Shares", int(listLen))
+	defer done()
+	if err != nil {
+		return err
+	}
+	for c.remaining() > 0 {
+		entry := &DissectNode{Name: "Key Share Entry", Offset: c.pos}
+		list.Children = append(list.Children, entry)
+		start := c.pos
+
+		node, group, err := c.uint16Field(entry, "Group")
+		if err != nil {
+			return err
+		}
+		node.Value = groupValue(group)
+		entry.Value = GetIANAGroup(group)
+
+		_, keyLen, err := c.uint16Field(entry, "Key Exchange Length")
+		if err != nil {
+			return err
+		}
+		if _, _, err := c.field(entry, "Key Exchange", int(keyLen)); err != nil {
+			return err
+		}
+		entry.Length = c.pos - start
+	}
+	return nil
+}
+
+func dissectPrefixedList16(c *dissectCursor, parent *DissectNode, listName, itemName string, describe func(uint16) string) error {
+	_, length, err := c.uint16Field(parent, listName+" Length")
+	if err != nil {
+		return err
+	}
+	return dissectList16(c, parent, listName, itemName, int(length), describe)
+}
+
+func dissectList16(c *dissectCursor, parent *DissectNode, listName, itemName string, length int, describe func(uint16) string) error {
+	list, done, err := c.container(parent, listName, length)
+	defer done()
+	if err != nil {
+		return err
+	}
+	list.Value = fmt.Sprintf("%d items", length/2)
+	for c.remaining() > 0 {
+		node, v, err := c.uint16Field(list, itemName)
+		if err != nil {
+			return err
+		}
+		node.Value = describe(v)
+	}
+	return nil
+}
+
+func dissectPrefixedList8(c *dissectCursor, parent *DissectNode, listName, itemName string, lookup func(uint8) (IanaEntry, bool)) error {
+	_, length, err := c.uint8Field(parent, listName+" Length")
+	if err != nil {
+		return err
+	}
+	list, done, err := c.container(parent, listName, int(length))
+	defer done()
+	if err != nil {
+		return err
+	}
+	for c.remaining() > 0 {
+		node, v, err := c.uint8Field(list, itemName)
+		if err != nil {
+			return err
+		}
+		node.Value = uint8Value(v, lookup)
+	}
+	return nil
+}
+
+func extensionName(ext uint16) string {
+	entry, ok := LookupExtension(ext)
+	return ianaName(entry, ok, ext, "Extension")
+}
+
+func versionValue(v uint16) string {
+	return fmt.Sprintf("%s (0x%04x)", GetIANAVersion(v), v)
+}
+
+func ciphersuiteValue(v uint16) string {
+	return fmt.Sprintf("%s (0x%04x)", GetIANACiphersuite(v), v)
+}
+
+func groupValue(v uint16) string {
+	return fmt.Sprintf("%s (0x%04x)", GetIANAGroup(v), v)
+}
+
+func signatureSchemeValue(v uint16) string {
+	return fmt.Sprintf("%s (0x%04x)", GetIANASignatureScheme(v), v)
+}
+
+func uint8Value(v uint8, lookup func(uint8) (IanaEntry, bool)) string {
+	if entry, ok := lookup(v); ok {
+		return fmt.Sprintf("%s (%d)", entry.Name, v)
+	}
+	return fmt.Sprintf("%d", v)
+}
+
+// WriteText renders the dissection in the style of a Wireshark packet detail
+// pane, with the offset and length of each field in the left hand columns
+func (d *Dissection) WriteText(w io.Writer) error {
+	for _, field := range d.Fields {
+		if err := writeNode(w, field, 0); err != nil {
+			return err
+		}
+	}
+	if !d.Complete {
+		_, err := fmt.Fprintln(w, "[dissection incomplete]")
+		return err
+	}
+	return nil
+}
+
+// String returns the text rendering of the dissection
+func (d *Dissection) String() string {
+	var b strings.Builder
+	_ = d.WriteText(&b)
+	return b.String()
+}
+
+func writeNode(w io.Writer, node *DissectNode, depth int) error {
+	line := fmt.Sprintf("[%5d:%5d] %s%s", node.Offset, node.Length, strings.Repeat("    ", depth), node.Name)
+	switch {
+	case node.Value != "":
+		line += ": " + node.Value
+	case len(node.Raw) > 0 && len(node.Children) == 0:
+		line += ": " + hex.EncodeToString(node.Raw)
+	}
+	if node.Error != "" {
+		line += "  [!] " + node.Error
+	}
+	if _, err := fmt.Fprintln(w, line); err != nil {
+		return err
+	}
+	for _, child := range node.Children {
+		if err := writeNode(w, child, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package dactyloscopy_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDissect(t *testing.T) {
+	hello := goClientHello(t, &tls.Config{ServerName: "example.com", NextProtos: []string{"h2", "http/1.1"}})
+
+	tests := []struct {
+		name         string
+		input        []byte
+		wantErr      bool
+		wantComplete bool
+		wantText     []string
+	}{
+		{
+			name:         "crypto/tls",
+			input:        hello,
+			wantComplete: true,
+			wantText: []string{
+				"Handshake Protocol: Client Hello",
+				"Server Name: example.com",
+				"TLS_AES_128_GCM_SHA256 (0x1301)",
+				"ALPN Next Protocol: h2 (HTTP/2 over TLS)",
+				"Type: supported_versions (43)",
+			},
+		},
+		{
+			// The fields leading up to the truncation are still there
+			name:    "truncated",
+			input:   hello[:120],
+			wantErr: true,
+			wantText: []string{
+				"Random",
+				"Cipher Suite",
+				"[!]",
+				"[dissection incomplete]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := dactyloscopy.Dissect(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NotNil(t, d)
+			assert.Equal(t, tt.wantComplete, d.Complete)
+
+			// The record should account for every byte of the input
+			assert.Equal(t, len(tt.input), d.Fields[0].Length)
+
+			text := d.String()
+			for _, want := range tt.wantText {
+				assert.Contains(t, text, want)
+			}
+		})
+	}
+}
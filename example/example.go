@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 
 	"github.com/LeeBrotherston/dactyloscopy"
 	"github.com/google/gopacket"
@@ -34,6 +35,30 @@ import (
 	"github.com/google/gopacket/tcpassembly/tcpreader"
 )
 
+// summary keeps running totals of what we have seen, printed once the capture
+// has been fully processed
+var summary struct {
+	sync.Mutex
+	pq dactyloscopy.PQCounts
+}
+
+func recordHello(fp *dactyloscopy.Fingerprint) {
+	summary.Lock()
+	defer summary.Unlock()
+	summary.pq.Add(fp)
+}
+
+func printSummary() {
+	summary.Lock()
+	defer summary.Unlock()
+	output, err := json.Marshal(summary.pq)
+	if err != nil {
+		log.Printf("could not marshal summary: %v", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "summary: %s\n", output)
+}
+
 func doSniff(device string, file string) error {
 	var (
 		handle gopacket.PacketDataSource
@@ -102,6 +127,8 @@ func doSniff(device string, file string) error {
 			err = clientHello.ProcessClientHello(packet.ApplicationLayer().Payload())
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
+			} else {
+				recordHello(&clientHello)
 			}
 
 			output, err := json.Marshal(clientHello)
@@ -111,6 +138,9 @@ func doSniff(device string, file string) error {
 			fmt.Printf("%s\n", output)
 		}
 	}
+	assembler.FlushAll()
+	streamFactory.wg.Wait()
+	printSummary()
 	return nil
 }
 
@@ -166,11 +196,17 @@ func main() {
 
 // TLS stream factory and stream for TCP reassembly
 
-type tlsStreamFactory struct{}
+type tlsStreamFactory struct {
+	wg sync.WaitGroup
+}
 
 func (f *tlsStreamFactory) New(netFlow, tcpFlow gopacket.Flow) tcpassembly.Stream {
 	r := tcpreader.NewReaderStream()
-	go processTLSStream(&r)
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		processTLSStream(&r)
+	}()
 	return &r
 }
 
@@ -185,6 +221,7 @@ func processTLSStream(r *tcpreader.ReaderStream) {
 			var clientHello dactyloscopy.Fingerprint
 			err = clientHello.ProcessClientHello(buf[:n])
 			if err == nil {
+				recordHello(&clientHello)
 				output, _ := json.Marshal(clientHello)
 				fmt.Printf("%s\n", output)
 			}
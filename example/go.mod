@@ -1,6 +1,6 @@
 module example
 
-go 1.25.0
+go 1.27.0
 
 replace github.com/LeeBrotherston/dactyloscopy => ./../
 
@@ -38,11 +38,11 @@ func (f *Fingerprint) handleExtension(extensionType uint16, extContent cryptobyt
 		f.Extensions = append(f.Extensions, extensionType)
 
 	case ExtPadding:
-		// Padding handling
-		err := skip16(extContent)
-		if err != nil {
-			return fmt.Errorf("could not read extension padding, err=[%w]", err)
-		}
+		// Padding handling, we don't include this in the extension list but the
+		// presence and size are useful for identifying the TLS library.  The
+		// body is opaque zero bytes, and may be as short as 0 or 1 byte
+		f.Padding = true
+		f.PaddingLen = len(extContent)
 	case ExtEllipticCurves:
 		// ellipticCurves
 		err := read16Length16Pair(&extContent, &f.ECurves)
@@ -0,0 +1,248 @@
+package dactyloscopy
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ExtensionOrder describes how a client orders its extensions across
+// connections
+type ExtensionOrder string
+
+const (
+	// ExtensionOrderUnknown means there weren't enough comparable samples
+	ExtensionOrderUnknown ExtensionOrder = "unknown"
+	// ExtensionOrderFixed means every sample used the same order
+	ExtensionOrderFixed ExtensionOrder = "fixed"
+	// ExtensionOrderRandomised means the order changes with no stable positions
+	ExtensionOrderRandomised ExtensionOrder = "randomised"
+	// ExtensionOrderPartiallyFixed means the order changes, but some
+	// extensions always appear in the same place (e.g. pre_shared_key last)
+	ExtensionOrderPartiallyFixed ExtensionOrder = "partially_fixed"
+)
+
+// greaseMarker stands in for any GREASE value when comparing orders, as the
+// value itself is randomised
+const greaseMarker uint16 = 0x0a0a
+
+// FixedPosition is an extension which appeared at the same position in every
+// sample.  Position counts from 0 at the start, or from -1 at the end when
+// FromEnd is set
+type FixedPosition struct {
+	Extension uint16 `json:"extension"`
+	Name      string `json:"name"`
+	Position  int    `json:"position"`
+	FromEnd   bool   `json:"from_end,omitempty"`
+}
+
+func (p FixedPosition) String() string {
+	switch {
+	case p.FromEnd && p.Position == -1:
+		return p.Name + " last"
+	case p.FromEnd:
+		return fmt.Sprintf("%s at %d from end", p.Name, -p.Position)
+	case p.Position == 0:
+		return p.Name + " first"
+	default:
+		return fmt.Sprintf("%s at position %d", p.Name, p.Position)
+	}
+}
+
+// ExtensionOrderAnalysis is the result of comparing the extension order of a
+// number of fingerprints believed to come from the same client
+type ExtensionOrderAnalysis struct {
+	Order ExtensionOrder `json:"order"`
+	// Samples is the number of fingerprints which shared the most common
+	// extension set, and so were compared, Mismatched is the number ignored
+	Samples    int `json:"samples"`
+	Mismatched int `json:"mismatched,omitempty"`
+	// DistinctOrders is the number of different orderings observed
+	DistinctOrders int             `json:"distinct_orders"`
+	FixedPositions []FixedPosition `json:"fixed_positions,omitempty"`
+	// Canonical is a fingerprint for the client with the extensions sorted,
+	// so that it is stable regardless of the order of any one connection
+	Canonical *Fingerprint `json:"canonical,omitempty"`
+}
+
+// AnalyseExtensionOrder determines whether the extension order of a client is
+// fixed, randomised, or partially fixed.  All of the fingerprints should be
+// from a single client (e.g. grouped by source IP or session).  Only samples
+// with the most common set of extensions are compared, so that the odd
+// resumption or retry with a different set doesn't look like randomisation.
+func AnalyseExtensionOrder(fps []*Fingerprint) ExtensionOrderAnalysis {
+	analysis := ExtensionOrderAnalysis{Order: ExtensionOrderUnknown}
+
+	// Group the samples by their extension set
+	var (
+		bestKey string
+		groups  = map[string][][]uint16{}
+		firsts  = map[string]*Fingerprint{}
+	)
+	for _, fp := range fps {
+		if fp == nil {
+			continue
+		}
+		order := normaliseGrease(fp.Extensions)
+		key := sliceToDash16(sortNumericAsc(order))
+		groups[key] = append(groups[key], order)
+		if firsts[key] == nil {
+			firsts[key] = fp
+		}
+		if len(groups[key]) > len(groups[bestKey]) {
+			bestKey = key
+		}
+	}
+	orders := groups[bestKey]
+	if len(orders) == 0 {
+		return analysis
+	}
+
+	analysis.Samples = len(orders)
+	for key, group := range groups {
+		if key != bestKey {
+			analysis.Mismatched += len(group)
+		}
+	}
+	analysis.Canonical = canonicalFingerprint(firsts[bestKey])
+
+	distinct := map[string]bool{}
+	for _, order := range orders {
+		distinct[sliceToDash16(order)] = true
+	}
+	analysis.DistinctOrders = len(distinct)
+
+	if analysis.Samples < 2 {
+		return analysis
+	}
+	if analysis.DistinctOrders == 1 {
+		analysis.Order = ExtensionOrderFixed
+		return analysis
+	}
+
+	analysis.FixedPositions = fixedPositions(orders)
+	if len(analysis.FixedPositions) > 0 {
+		analysis.Order = ExtensionOrderPartiallyFixed
+	} else {
+		analysis.Order = ExtensionOrderRandomised
+	}
+	return analysis
+}
+
+// fixedPositions finds extensions which are at the same index in every order,
+// counting from both the start and the end.  All orders contain the same set
+// of extensions, and so are the same length
+func fixedPositions(orders [][]uint16) []FixedPosition {
+	var fixed []FixedPosition
+	length := len(orders[0])
+	seen := map[int]bool{}
+
+	for i := 0; i < length; i++ {
+		if ext, ok := sameAt(orders, i); ok {
+			seen[i] = true
+			fixed = append(fixed, FixedPosition{Extension: ext, Name: positionName(ext), Position: i})
+		}
+	}
+	for i := length - 1; i >= 0; i-- {
+		if seen[i] {
+			// Already reported counting from the start
+			continue
+		}
+		if ext, ok := sameAt(orders, i); ok {
+			fixed = append(fixed, FixedPosition{Extension: ext, Name: positionName(ext), Position: i - length, FromEnd: true})
+		}
+	}
+
+	// Report anything at the very end relative to the end, "pre_shared_key
+	// last" being more meaningful than "pre_shared_key at position 17"
+	for i := range fixed {
+		if !fixed[i].FromEnd && fixed[i].Position == length-1 && length > 1 {
+			fixed[i].Position = -1
+			fixed[i].FromEnd = true
+		}
+	}
+	return fixed
+}
+
+func positionName(ext uint16) string {
+	if ext == greaseMarker {
+		return "GREASE"
+	}
+	return extensionName(ext)
+}
+
+func sameAt(orders [][]uint16, i int) (uint16, bool) {
+	ext := orders[0][i]
+	for _, order := range orders[1:] {
+		if order[i] != ext {
+			return 0, false
+		}
+	}
+	return ext, true
+}
+
+func normaliseGrease(values []uint16) []uint16 {
+	out := make([]uint16, len(values))
+	for i, v := range values {
+		if isGrease(v) {
+			v = greaseMarker
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// canonicalFingerprint returns a copy of the fingerprint with the extensions
+// sorted and GREASE removed, and the hashes recalculated to match
+func canonicalFingerprint(fp *Fingerprint) *Fingerprint {
+	canonical := *fp
+	canonical.Extensions = sortNumericAsc(withoutGrease(fp.Extensions))
+	// The hash functions can only fail if the md5/sha256 writer does, which it
+	// won't, so the resulting empty values are as good as anything here
+	_ = canonical.generateJA3()
+	_ = canonical.generateJA3N()
+	_ = canonical.generateJA4()
+	return &canonical
+}
+
+// ExtensionOrderAggregator collects fingerprints per client so that their
+// extension order can be analysed.  The client key is up to the caller, for
+// example a source IP address or session identifier.  It is safe for
+// concurrent use.
+type ExtensionOrderAggregator struct {
+	// MaxSamples limits the number of fingerprints kept per client, the oldest
+	// being discarded first.  Zero means unlimited
+	MaxSamples int
+
+	mu      sync.Mutex
+	clients map[string][]*Fingerprint
+}
+
+// Add records a fingerprint for the given client
+func (a *ExtensionOrderAggregator) Add(client string, fp *Fingerprint) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.clients == nil {
+		a.clients = map[string][]*Fingerprint{}
+	}
+	samples := append(a.clients[client], fp)
+	if a.MaxSamples > 0 && len(samples) > a.MaxSamples {
+		samples = samples[len(samples)-a.MaxSamples:]
+	}
+	a.clients[client] = samples
+}
+
+// Analyse returns the extension order analysis for the given client
+func (a *ExtensionOrderAggregator) Analyse(client string) ExtensionOrderAnalysis {
+	a.mu.Lock()
+	samples := slices.Clone(a.clients[client])
+	a.mu.Unlock()
+	return AnalyseExtensionOrder(samples)
+}
+
+// Forget discards the samples held for a client
+func (a *ExtensionOrderAggregator) Forget(client string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.clients, client)
+}
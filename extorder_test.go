@@ -0,0 +1,124 @@
+package dactyloscopy_test
+
+import (
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyseExtensionOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		orders         [][]uint16
+		wantOrder      dactyloscopy.ExtensionOrder
+		wantSamples    int
+		wantMismatched int
+		wantDistinct   int
+		wantFixed      []string
+	}{
+		{
+			name:      "no samples",
+			wantOrder: dactyloscopy.ExtensionOrderUnknown,
+		},
+		{
+			name:         "single sample",
+			orders:       [][]uint16{{0, 10, 43}},
+			wantOrder:    dactyloscopy.ExtensionOrderUnknown,
+			wantSamples:  1,
+			wantDistinct: 1,
+		},
+		{
+			name:         "fixed with GREASE",
+			orders:       [][]uint16{{0x0a0a, 0, 10, 43}, {0x5a5a, 0, 10, 43}, {0xdada, 0, 10, 43}},
+			wantOrder:    dactyloscopy.ExtensionOrderFixed,
+			wantSamples:  3,
+			wantDistinct: 1,
+		},
+		{
+			name:         "randomised",
+			orders:       [][]uint16{{0, 10, 43}, {43, 0, 10}, {10, 43, 0}},
+			wantOrder:    dactyloscopy.ExtensionOrderRandomised,
+			wantSamples:  3,
+			wantDistinct: 3,
+		},
+		{
+			// Chrome style, GREASE first and pre_shared_key last
+			name:         "partially fixed",
+			orders:       [][]uint16{{0x1a1a, 0, 10, 43, 41}, {0x2a2a, 43, 10, 0, 41}, {0x3a3a, 10, 0, 43, 41}},
+			wantOrder:    dactyloscopy.ExtensionOrderPartiallyFixed,
+			wantSamples:  3,
+			wantDistinct: 3,
+			wantFixed:    []string{"GREASE first", "pre_shared_key last"},
+		},
+		{
+			// e.g. a resumption without pre_shared_key
+			name:           "different extension set ignored",
+			orders:         [][]uint16{{0, 10, 43, 41}, {0, 10, 43}, {0, 10, 43, 41}},
+			wantOrder:      dactyloscopy.ExtensionOrderFixed,
+			wantSamples:    2,
+			wantMismatched: 1,
+			wantDistinct:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fps []*dactyloscopy.Fingerprint
+			for _, order := range tt.orders {
+				fps = append(fps, &dactyloscopy.Fingerprint{
+					TLSVersion:  dactyloscopy.VersionTLS12,
+					Ciphersuite: []uint16{0x1301},
+					Extensions:  order,
+				})
+			}
+
+			analysis := dactyloscopy.AnalyseExtensionOrder(fps)
+			assert.Equal(t, tt.wantOrder, analysis.Order)
+			assert.Equal(t, tt.wantSamples, analysis.Samples)
+			assert.Equal(t, tt.wantMismatched, analysis.Mismatched)
+			assert.Equal(t, tt.wantDistinct, analysis.DistinctOrders)
+			var fixed []string
+			for _, p := range analysis.FixedPositions {
+				fixed = append(fixed, p.String())
+			}
+			assert.ElementsMatch(t, tt.wantFixed, fixed)
+		})
+	}
+}
+
+func TestExtensionOrderCanonical(t *testing.T) {
+	// The canonical fingerprint should be the same whatever order was seen
+	var ja3 []string
+	for _, order := range [][]uint16{{0x1a1a, 0, 10, 43, 41}, {0x2a2a, 43, 10, 0, 41}} {
+		analysis := dactyloscopy.AnalyseExtensionOrder([]*dactyloscopy.Fingerprint{{
+			TLSVersion:  dactyloscopy.VersionTLS12,
+			Ciphersuite: []uint16{0x1301},
+			Extensions:  order,
+			ECurves:     []uint16{0x001d},
+			EcPointFmt:  []uint8{0},
+		}})
+		require.NotNil(t, analysis.Canonical)
+		assert.Equal(t, []uint16{0, 10, 41, 43}, analysis.Canonical.Extensions)
+		assert.Equal(t, analysis.Canonical.JA3N, analysis.Canonical.JA3)
+		ja3 = append(ja3, analysis.Canonical.JA3)
+	}
+	assert.Equal(t, ja3[0], ja3[1])
+}
+
+func TestExtensionOrderAggregator(t *testing.T) {
+	agg := &dactyloscopy.ExtensionOrderAggregator{MaxSamples: 2}
+	for _, order := range [][]uint16{{43, 0, 10}, {0, 10, 43}, {0, 10, 43}} {
+		agg.Add("192.0.2.1", &dactyloscopy.Fingerprint{Extensions: order})
+	}
+
+	// Only the last two samples are kept, and they agree
+	analysis := agg.Analyse("192.0.2.1")
+	assert.Equal(t, dactyloscopy.ExtensionOrderFixed, analysis.Order)
+	assert.Equal(t, 2, analysis.Samples)
+
+	assert.Equal(t, dactyloscopy.ExtensionOrderUnknown, agg.Analyse("198.51.100.1").Order)
+	agg.Forget("192.0.2.1")
+	assert.Equal(t, dactyloscopy.ExtensionOrderUnknown, agg.Analyse("192.0.2.1").Order)
+}
@@ -1,14 +1,66 @@
 package dactyloscopy
 
 import (
+	"crypto/mlkem"
+	crand "crypto/rand"
 	"math/rand"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/cryptobyte"
 )
 
+func TestKeyShareHybridOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   uint16
+		ecSize  int
+		kemLast bool
+	}{
+		{"X25519MLKEM768", 0x11ec, 32, false},
+		{"X25519Kyber768Draft00", 0x6399, 32, true},
+		{"SecP256r1MLKEM768", 0x11eb, 65, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			share, err := keyShare(tt.group, crand.Reader)
+			require.NoError(t, err)
+			require.Len(t, share, tt.ecSize+mlkem.EncapsulationKeySize768)
+
+			// The ML-KEM share only decodes from where it was put
+			kem := share[:mlkem.EncapsulationKeySize768]
+			if tt.kemLast {
+				kem = share[tt.ecSize:]
+			}
+			_, err = mlkem.NewEncapsulationKey768(kem)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHandleExtensionPadding(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{"empty", []byte{}},
+		{"one byte", []byte{0}},
+		{"two bytes", []byte{0, 0}},
+		{"typical", make([]byte, 126)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Fingerprint{}
+			require.NoError(t, f.handleExtension(ExtPadding, cryptobyte.String(tt.body)))
+			assert.True(t, f.Padding)
+			assert.Equal(t, len(tt.body), f.PaddingLen)
+			assert.Empty(t, f.Extensions)
+		})
+	}
+}
+
 func TestSortNumeric(t *testing.T) {
 	var (
 		inU32 []uint32
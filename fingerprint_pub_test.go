@@ -1,7 +1,11 @@
 package dactyloscopy_test
 
 import (
+	"crypto/tls"
+	"io"
+	"net"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/LeeBrotherston/dactyloscopy"
@@ -39,6 +43,48 @@ func TestProcessClientHello(t *testing.T) {
 	}
 }
 
+func TestProcessClientHelloGo(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *tls.Config
+		wantVersions []uint16
+		wantSNI      string
+	}{
+		{
+			name:         "TLS 1.3",
+			config:       &tls.Config{ServerName: "example.com"},
+			wantVersions: []uint16{dactyloscopy.VersionTLS13, dactyloscopy.VersionTLS12},
+			wantSNI:      "example.com",
+		},
+		{
+			name:         "TLS 1.2 only",
+			config:       &tls.Config{ServerName: "example.com", MaxVersion: tls.VersionTLS12},
+			wantVersions: []uint16{dactyloscopy.VersionTLS12},
+			wantSNI:      "example.com",
+		},
+		{
+			name:         "IP address",
+			config:       &tls.Config{ServerName: "192.0.2.1"},
+			wantVersions: []uint16{dactyloscopy.VersionTLS13, dactyloscopy.VersionTLS12},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp, err := dactyloscopy.ProcessClientHello(goClientHello(t, tt.config))
+			if err != nil {
+				t.Fatalf("ProcessClientHello() error = %v", err)
+			}
+			if !slices.Equal(fp.SupportedVersions, tt.wantVersions) {
+				t.Errorf("SupportedVersions = %v, want %v", fp.SupportedVersions, tt.wantVersions)
+			}
+			if fp.SNI != tt.wantSNI {
+				t.Errorf("SNI = %v, want %v", fp.SNI, tt.wantSNI)
+			}
+		})
+	}
+}
+
 func TestFingerprint_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -153,3 +199,26 @@ func FuzzProcessClientHello(f *testing.F) {
 		_ = fp.ProcessClientHello(data)
 	})
 }
+
+// goClientHello captures the client hello record sent by crypto/tls using the
+// supplied config, for use as a known good input
+func goClientHello(t *testing.T, config *tls.Config) []byte {
+	t.Helper()
+	client, server := net.Pipe()
+	defer server.Close() // nolint:errcheck
+
+	go func() {
+		_ = tls.Client(client, config).Handshake()
+	}()
+	defer client.Close() // nolint:errcheck
+
+	hdr := make([]byte, 5)
+	if _, err := io.ReadFull(server, hdr); err != nil {
+		t.Fatal(err)
+	}
+	body := make([]byte, int(hdr[3])<<8|int(hdr[4]))
+	if _, err := io.ReadFull(server, body); err != nil {
+		t.Fatal(err)
+	}
+	return append(hdr, body...)
+}
@@ -0,0 +1,109 @@
+package dactyloscopy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DBEntry is a known fingerprint in a FingerprintDB.  Any of the hashes may be
+// empty, but at least one is needed for the entry to ever match
+type DBEntry struct {
+	Name string   `json:"name"`
+	JA3  string   `json:"ja3,omitempty"`
+	JA3N string   `json:"ja3n,omitempty"`
+	JA4  string   `json:"ja4,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// DBMatch is the result of looking a fingerprint up in a FingerprintDB
+type DBMatch struct {
+	DBEntry
+	// MatchedOn is the hash which matched, "ja4", "ja3n" or "ja3"
+	MatchedOn string `json:"matched_on"`
+}
+
+// FingerprintDB maps fingerprint hashes to the clients they identify.  It is
+// safe for concurrent lookups once built
+type FingerprintDB struct {
+	Entries []DBEntry
+
+	byJA3  map[string]int
+	byJA3N map[string]int
+	byJA4  map[string]int
+}
+
+// NewFingerprintDB indexes the entries.  Where two entries share a hash, the
+// first one wins
+func NewFingerprintDB(entries []DBEntry) *FingerprintDB {
+	db := &FingerprintDB{
+		Entries: entries,
+		byJA3:   map[string]int{},
+		byJA3N:  map[string]int{},
+		byJA4:   map[string]int{},
+	}
+	for i, entry := range entries {
+		for _, index := range []struct {
+			hash string
+			into map[string]int
+		}{
+			{entry.JA3, db.byJA3},
+			{entry.JA3N, db.byJA3N},
+			{entry.JA4, db.byJA4},
+		} {
+			if _, exists := index.into[index.hash]; index.hash != "" && !exists {
+				index.into[index.hash] = i
+			}
+		}
+	}
+	return db
+}
+
+// LoadFingerprintDB reads a JSON array of DBEntry
+func LoadFingerprintDB(r io.Reader) (*FingerprintDB, error) {
+	var entries []DBEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decoding fingerprint DB: %w", err)
+	}
+	return NewFingerprintDB(entries), nil
+}
+
+// LoadFingerprintDBFile reads a JSON fingerprint DB from a file.  The DB is an
+// array of objects with name, ja3, ja3n, ja4 and tags fields:
+//
+//	[{"name": "curl", "ja4": "t13d3112h2_e8f1e7e78f70_6bebaf5329ac", "tags": ["cli"]}]
+func LoadFingerprintDBFile(path string) (*FingerprintDB, error) {
+	file, err := os.Open(path) // #nosec G304 -- path is supplied by the operator
+	if err != nil {
+		return nil, fmt.Errorf("opening fingerprint DB: %w", err)
+	}
+	defer file.Close() // nolint:errcheck
+	return LoadFingerprintDB(file)
+}
+
+// Lookup finds the entry for a fingerprint, trying JA4 first as the most
+// specific, then JA3N so that clients which randomise their extension order
+// still match, and finally JA3
+func (db *FingerprintDB) Lookup(f *Fingerprint) (DBMatch, bool) {
+	if db == nil || f == nil {
+		return DBMatch{}, false
+	}
+	for _, index := range []struct {
+		name string
+		hash string
+		from map[string]int
+	}{
+		{"ja4", f.JA4, db.byJA4},
+		{"ja3n", f.JA3N, db.byJA3N},
+		{"ja3", f.JA3, db.byJA3},
+	} {
+		if index.hash == "" {
+			continue
+		}
+		if i, ok := index.from[index.hash]; ok {
+			return DBMatch{DBEntry: db.Entries[i], MatchedOn: index.name}, true
+		}
+	}
+	return DBMatch{}, false
+}
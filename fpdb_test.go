@@ -0,0 +1,46 @@
+package dactyloscopy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+func TestFingerprintDB(t *testing.T) {
+	db, err := dactyloscopy.LoadFingerprintDB(strings.NewReader(`[
+		{"name": "by ja3", "ja3": "aaaa"},
+		{"name": "by ja3n", "ja3": "bbbb", "ja3n": "cccc", "tags": ["browser"]},
+		{"name": "by ja4", "ja4": "t13d", "ja3": "aaaa"}
+	]`))
+	require.NoError(t, err)
+
+	match, ok := db.Lookup(&dactyloscopy.Fingerprint{JA3: "aaaa"})
+	require.True(t, ok)
+	assert.Equal(t, "by ja3", match.Name)
+	assert.Equal(t, "ja3", match.MatchedOn)
+
+	// JA3N is preferred to JA3, so a randomised extension order still matches
+	match, ok = db.Lookup(&dactyloscopy.Fingerprint{JA3: "ffff", JA3N: "cccc"})
+	require.True(t, ok)
+	assert.Equal(t, "by ja3n", match.Name)
+	assert.Equal(t, []string{"browser"}, match.Tags)
+
+	match, ok = db.Lookup(&dactyloscopy.Fingerprint{JA3: "aaaa", JA4: "t13d"})
+	require.True(t, ok)
+	assert.Equal(t, "by ja4", match.Name)
+	assert.Equal(t, "ja4", match.MatchedOn)
+
+	_, ok = db.Lookup(&dactyloscopy.Fingerprint{JA3: "ffff"})
+	assert.False(t, ok)
+
+	var nilDB *dactyloscopy.FingerprintDB
+	_, ok = nilDB.Lookup(&dactyloscopy.Fingerprint{JA3: "aaaa"})
+	assert.False(t, ok)
+
+	_, err = dactyloscopy.LoadFingerprintDB(strings.NewReader(`{"name": "not a list"}`))
+	assert.Error(t, err)
+}
@@ -1,17 +1,18 @@
 module github.com/LeeBrotherston/dactyloscopy
 
-go 1.25.0
+go 1.27.0
 
 require (
 	github.com/google/gopacket v1.1.19
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/crypto v0.51.0
+	golang.org/x/net v0.54.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/net v0.54.0 // indirect
 	golang.org/x/sys v0.44.0 // indirect
+	golang.org/x/text v0.37.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
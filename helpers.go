@@ -8,20 +8,6 @@ import (
 	"golang.org/x/crypto/cryptobyte"
 )
 
-func skip16(data cryptobyte.String) error {
-	var skipsize uint16 = 0
-	if !data.ReadUint16(&skipsize) {
-		return fmt.Errorf("could not read skip size, data=[%X]", data)
-	}
-
-	if skipsize > 0 {
-		if !data.Skip(int(skipsize)) {
-			return fmt.Errorf("could not skip")
-		}
-	}
-	return nil
-}
-
 func readXLengthYVal[Y uint8 | uint16 | uint32 | uint64](dataBlock *cryptobyte.String, output *[]Y, lengthSize int) error {
 	var (
 		singleValue Y
@@ -92,8 +78,10 @@ func read16Length8Pair(dataBlock *cryptobyte.String, output *[]uint8) error {
 	return readXLengthYPair(dataBlock, output)
 }
 
+// read8Length16Pair reads a list of 16 bit values with a single byte length
+// prefix, as used by supported_versions
 func read8Length16Pair(dataBlock *cryptobyte.String, output *[]uint16) error {
-	return readXLengthYPair(dataBlock, output)
+	return readXLengthYVal(dataBlock, output, 1)
 }
 
 // sliceToDash16 converts a slice of number values and make a dash delimited
@@ -139,3 +127,9 @@ func sortNumeric[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 |
 	})
 	return sortableSlice
 }
+
+// isGrease reports whether a 16 bit value is one of the reserved GREASE values
+// (RFC 8701), which all take the form 0x?A?A with both bytes equal
+func isGrease(value uint16) bool {
+	return value&0x0f0f == 0x0a0a && value>>8 == value&0xff
+}
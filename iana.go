@@ -0,0 +1,123 @@
+package dactyloscopy
+
+import "fmt"
+
+//go:generate go run ./internal/ianagen -registries internal/ianagen/registries -out .
+
+// IanaEntry describes a single value from one of the IANA TLS registries.
+// DTLSOK is only meaningful for registries which carry that column, Deprecated
+// is set where the value is discouraged or prohibited by a later RFC
+type IanaEntry struct {
+	Name        string `json:"name"`
+	DTLSOK      bool   `json:"dtls_ok"`
+	Recommended bool   `json:"recommended"`
+	Deprecated  bool   `json:"deprecated"`
+}
+
+// CiphersuiteInfo is the IANA registry information for a ciphersuite
+type CiphersuiteInfo = IanaEntry
+
+// GetIanaEntry returns the IANA registry information for a ciphersuite, as
+// stored in Fingerprint.Ciphersuite.  Unknown suites return a zero value, use
+// LookupCiphersuite if you need to distinguish these
+func GetIanaEntry(suite uint16) CiphersuiteInfo {
+	return ianaCiphersuites[suite]
+}
+
+// GetIANAExtension returns the name of an extension type
+func GetIANAExtension(extension uint16) string {
+	return IanaExtension(extension).String()
+}
+
+// LookupCiphersuite returns the registry entry for a ciphersuite
+func LookupCiphersuite(suite uint16) (IanaEntry, bool) {
+	entry, ok := ianaCiphersuites[suite]
+	return entry, ok
+}
+
+// LookupExtension returns the registry entry for an extension type
+func LookupExtension(extension uint16) (IanaEntry, bool) {
+	entry, ok := ianaExtensions[extension]
+	return entry, ok
+}
+
+// LookupGroup returns the registry entry for a supported group
+func LookupGroup(group uint16) (IanaEntry, bool) {
+	entry, ok := ianaSupportedGroups[group]
+	return entry, ok
+}
+
+// LookupSignatureScheme returns the registry entry for a signature scheme
+func LookupSignatureScheme(scheme uint16) (IanaEntry, bool) {
+	entry, ok := ianaSignatureSchemes[scheme]
+	return entry, ok
+}
+
+// LookupECPointFormat returns the registry entry for an EC point format
+func LookupECPointFormat(format uint8) (IanaEntry, bool) {
+	entry, ok := ianaECPointFormats[format]
+	return entry, ok
+}
+
+// LookupPSKKeyExchangeMode returns the registry entry for a PSK key exchange mode
+func LookupPSKKeyExchangeMode(mode uint8) (IanaEntry, bool) {
+	entry, ok := ianaPSKKeyExchangeModes[mode]
+	return entry, ok
+}
+
+// LookupCompressionMethod returns the registry entry for a compression method
+func LookupCompressionMethod(method uint8) (IanaEntry, bool) {
+	entry, ok := ianaCompressionMethods[method]
+	return entry, ok
+}
+
+// LookupTLSVersion returns the entry for a protocol version, as found in the
+// record layer, client hello or supported_versions extension
+func LookupTLSVersion(version uint16) (IanaEntry, bool) {
+	entry, ok := tlsVersions[version]
+	return entry, ok
+}
+
+// LookupALPN returns the registry entry for an ALPN protocol ID
+func LookupALPN(protocol string) (IanaEntry, bool) {
+	entry, ok := ianaALPN[protocol]
+	return entry, ok
+}
+
+// GetIANACiphersuite returns the name of a ciphersuite, or a hex representation
+// if it is not known
+func GetIANACiphersuite(suite uint16) string {
+	entry, ok := LookupCiphersuite(suite)
+	return ianaName(entry, ok, suite, "Ciphersuite")
+}
+
+// GetIANAGroup returns the IANA name of a supported group (formerly known as
+// elliptic curves), or a hex representation if the group is not known
+func GetIANAGroup(group uint16) string {
+	entry, ok := LookupGroup(group)
+	return ianaName(entry, ok, group, "SupportedGroup")
+}
+
+// GetIANASignatureScheme returns the name of a signature scheme, or a hex
+// representation if it is not known
+func GetIANASignatureScheme(scheme uint16) string {
+	entry, ok := LookupSignatureScheme(scheme)
+	return ianaName(entry, ok, scheme, "SignatureScheme")
+}
+
+// GetIANAVersion returns the name of a protocol version, or a hex
+// representation if it is not known
+func GetIANAVersion(version uint16) string {
+	entry, ok := LookupTLSVersion(version)
+	return ianaName(entry, ok, version, "Version")
+}
+
+func ianaName(entry IanaEntry, ok bool, value uint16, kind string) string {
+	if ok {
+		return entry.Name
+	}
+	if isGrease(value) {
+		return fmt.Sprintf("GREASE(0x%04X)", value)
+	}
+	return fmt.Sprintf("%s(0x%04X)", kind, value)
+}
@@ -0,0 +1,44 @@
+// Code generated by ianagen from the IANA registry CSVs; DO NOT EDIT.
+
+package dactyloscopy
+
+// ianaALPN is the TLS Application-Layer Protocol Negotiation (ALPN) Protocol
+// IDs registry, keyed by the identification sequence sent on the wire
+var ianaALPN = map[string]IanaEntry{
+	"acme-tls/1":         {Name: "acme-tls/1"},
+	"c-webrtc":           {Name: "Confidential WebRTC Media and Data"},
+	"coap":               {Name: "CoAP"},
+	"dicom":              {Name: "DICOM"},
+	"doq":                {Name: "DoQ"},
+	"dot":                {Name: "DNS-over-TLS"},
+	"ftp":                {Name: "FTP"},
+	"h2":                 {Name: "HTTP/2 over TLS"},
+	"h2c":                {Name: "HTTP/2 over TCP"},
+	"h3":                 {Name: "HTTP/3"},
+	"http/0.9":           {Name: "HTTP/0.9"},
+	"http/1.0":           {Name: "HTTP/1.0"},
+	"http/1.1":           {Name: "HTTP/1.1"},
+	"imap":               {Name: "IMAP"},
+	"irc":                {Name: "IRC"},
+	"managesieve":        {Name: "ManageSieve"},
+	"mqtt":               {Name: "OASIS Message Queuing Telemetry Transport (MQTT)"},
+	"nnsp":               {Name: "NNTP (transit)"},
+	"nntp":               {Name: "NNTP (reading)"},
+	"ntske/1":            {Name: "Network Time Security Key Establishment, version 1"},
+	"pop3":               {Name: "POP3"},
+	"postgresql":         {Name: "PostgreSQL"},
+	"radius/1.0":         {Name: "RADIUS/1.0"},
+	"radius/1.1":         {Name: "RADIUS/1.1"},
+	"sip/2":              {Name: "SIP"},
+	"smb":                {Name: "SMB2"},
+	"spdy/1":             {Name: "SPDY/1"},
+	"spdy/2":             {Name: "SPDY/2"},
+	"spdy/3":             {Name: "SPDY/3"},
+	"stun.nat-discovery": {Name: "NAT discovery using Session Traversal Utilities for NAT (STUN)"},
+	"stun.turn":          {Name: "Traversal Using Relays around NAT (TURN)"},
+	"sunrpc":             {Name: "SunRPC"},
+	"tds/8.0":            {Name: "TDS/8.0"},
+	"webrtc":             {Name: "WebRTC Media and Data"},
+	"xmpp-client":        {Name: "XMPP jabber:client namespace"},
+	"xmpp-server":        {Name: "XMPP jabber:server namespace"},
+}
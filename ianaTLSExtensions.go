@@ -1,10 +1,8 @@
-package dactyloscopy
+// Code generated by ianagen from the IANA registry CSVs; DO NOT EDIT.
 
-func GetIANAExtension(extension uint16) string {
-	return IanaExtension(extension).String()
-}
+package dactyloscopy
 
-//go:generate stringer -type=IanaExtension
+// IanaExtension is a TLS extension type, which prints as its registry name
 type IanaExtension uint16
 
 const (
@@ -69,96 +67,93 @@ const (
 	sequence_number_encryption_algorithms  IanaExtension = 60
 	rrc                                    IanaExtension = 61
 	tls_flags                              IanaExtension = 62
+	next_protocol_negotiation              IanaExtension = 13172
+	application_settings_old               IanaExtension = 17513
+	application_settings                   IanaExtension = 17613
+	channel_id_old                         IanaExtension = 30031
+	channel_id                             IanaExtension = 30032
 	ech_outer_extensions                   IanaExtension = 64768
 	encrypted_client_hello                 IanaExtension = 65037
-	PrivateUse                             IanaExtension = 65280
 	renegotiation_info                     IanaExtension = 65281
+	encrypted_server_name                  IanaExtension = 65486
+
+	// PrivateUse is the first value reserved for private use, which earlier
+	// releases exported
+	PrivateUse IanaExtension = 65280
 )
 
-/*
-var ianaExtensions = map[uint16]string{
-	0:     "server_name",
-	1:     "max_fragment_length",
-	2:     "client_certificate_url",
-	3:     "trusted_ca_keys",
-	4:     "truncated_hmac",
-	5:     "status_request",
-	6:     "user_mapping",
-	7:     "client_authz",
-	8:     "server_authz",
-	9:     "cert_type",
-	10:    "supported_groups",
-	11:    "ec_point_formats",
-	12:    "srp",
-	13:    "signature_algorithms",
-	14:    "use_srtp",
-	15:    "heartbeat",
-	16:    "application_layer_protocol_negotiation",
-	17:    "status_request_v2",
-	18:    "signed_certificate_timestamp",
-	19:    "client_certificate_type",
-	20:    "server_certificate_type",
-	21:    "padding",
-	22:    "encrypt_then_mac",
-	23:    "extended_master_secret",
-	24:    "token_binding",
-	25:    "cached_info",
-	26:    "tls_lts",
-	27:    "compress_certificate",
-	28:    "record_size_limit",
-	29:    "pwd_protect",
-	30:    "pwd_clear",
-	31:    "password_salt",
-	32:    "ticket_pinning",
-	33:    "tls_cert_with_extern_psk",
-	34:    "delegated_credential",
-	35:    "session_ticket",
-	36:    "TLMSP",
-	37:    "TLMSP_proxying",
-	38:    "TLMSP_delegate",
-	39:    "supported_ekt_ciphers",
-	40:    "Reserved",
-	41:    "pre_shared_key",
-	42:    "early_data",
-	43:    "supported_versions",
-	44:    "cookie",
-	45:    "psk_key_exchange_modes",
-	46:    "Reserved",
-	47:    "certificate_authorities",
-	48:    "oid_filters",
-	49:    "post_handshake_auth",
-	50:    "signature_algorithms_cert",
-	51:    "key_share",
-	52:    "transparency_info",
-	53:    "connection_id (deprecated)",
-	54:    "connection_id",
-	55:    "external_id_hash",
-	56:    "external_session_id",
-	57:    "quic_transport_parameters",
-	58:    "ticket_request",
-	59:    "dnssec_chain",
-	60:    "sequence_number_encryption_algorithms",
-	61:    "rrc",
-	62:    "tls_flags",
-	2570:  "Reserved",
-	6682:  "Reserved",
-	10794: "Reserved",
-	14906: "Reserved",
-	19018: "Reserved",
-	23130: "Reserved",
-	27242: "Reserved",
-	31354: "Reserved",
-	35466: "Reserved",
-	39578: "Reserved",
-	43690: "Reserved",
-	47802: "Reserved",
-	51914: "Reserved",
-	56026: "Reserved",
-	60138: "Reserved",
-	64250: "Reserved",
-	64768: "ech_outer_extensions",
-	65037: "encrypted_client_hello",
-	65280: "Reserved for Private Use",
-	65281: "renegotiation_info",
+// ianaExtensions is the TLS ExtensionType Values registry, together with a
+// handful of widely deployed but unregistered extensions.
+// Deprecated is set where the registry marks the value as discouraged (D)
+var ianaExtensions = map[uint16]IanaEntry{
+	0x0000: {Name: "server_name", Recommended: true, Deprecated: false},
+	0x0001: {Name: "max_fragment_length", Recommended: false, Deprecated: false},
+	0x0002: {Name: "client_certificate_url", Recommended: false, Deprecated: false},
+	0x0003: {Name: "trusted_ca_keys", Recommended: false, Deprecated: false},
+	0x0004: {Name: "truncated_hmac", Recommended: false, Deprecated: true},
+	0x0005: {Name: "status_request", Recommended: true, Deprecated: false},
+	0x0006: {Name: "user_mapping", Recommended: false, Deprecated: false},
+	0x0007: {Name: "client_authz", Recommended: false, Deprecated: false},
+	0x0008: {Name: "server_authz", Recommended: false, Deprecated: false},
+	0x0009: {Name: "cert_type", Recommended: false, Deprecated: false},
+	0x000A: {Name: "supported_groups", Recommended: true, Deprecated: false},
+	0x000B: {Name: "ec_point_formats", Recommended: true, Deprecated: false},
+	0x000C: {Name: "srp", Recommended: false, Deprecated: false},
+	0x000D: {Name: "signature_algorithms", Recommended: true, Deprecated: false},
+	0x000E: {Name: "use_srtp", Recommended: true, Deprecated: false},
+	0x000F: {Name: "heartbeat", Recommended: false, Deprecated: false},
+	0x0010: {Name: "application_layer_protocol_negotiation", Recommended: true, Deprecated: false},
+	0x0011: {Name: "status_request_v2", Recommended: true, Deprecated: false},
+	0x0012: {Name: "signed_certificate_timestamp", Recommended: false, Deprecated: false},
+	0x0013: {Name: "client_certificate_type", Recommended: false, Deprecated: false},
+	0x0014: {Name: "server_certificate_type", Recommended: false, Deprecated: false},
+	0x0015: {Name: "padding", Recommended: true, Deprecated: false},
+	0x0016: {Name: "encrypt_then_mac", Recommended: true, Deprecated: false},
+	0x0017: {Name: "extended_master_secret", Recommended: true, Deprecated: false},
+	0x0018: {Name: "token_binding", Recommended: true, Deprecated: false},
+	0x0019: {Name: "cached_info", Recommended: false, Deprecated: false},
+	0x001A: {Name: "tls_lts", Recommended: false, Deprecated: false},
+	0x001B: {Name: "compress_certificate", Recommended: true, Deprecated: false},
+	0x001C: {Name: "record_size_limit", Recommended: true, Deprecated: false},
+	0x001D: {Name: "pwd_protect", Recommended: false, Deprecated: false},
+	0x001E: {Name: "pwd_clear", Recommended: false, Deprecated: false},
+	0x001F: {Name: "password_salt", Recommended: false, Deprecated: false},
+	0x0020: {Name: "ticket_pinning", Recommended: false, Deprecated: false},
+	0x0021: {Name: "tls_cert_with_extern_psk", Recommended: false, Deprecated: false},
+	0x0022: {Name: "delegated_credential", Recommended: true, Deprecated: false},
+	0x0023: {Name: "session_ticket", Recommended: true, Deprecated: false},
+	0x0024: {Name: "TLMSP", Recommended: false, Deprecated: false},
+	0x0025: {Name: "TLMSP_proxying", Recommended: false, Deprecated: false},
+	0x0026: {Name: "TLMSP_delegate", Recommended: false, Deprecated: false},
+	0x0027: {Name: "supported_ekt_ciphers", Recommended: false, Deprecated: false},
+	0x0029: {Name: "pre_shared_key", Recommended: true, Deprecated: false},
+	0x002A: {Name: "early_data", Recommended: true, Deprecated: false},
+	0x002B: {Name: "supported_versions", Recommended: true, Deprecated: false},
+	0x002C: {Name: "cookie", Recommended: true, Deprecated: false},
+	0x002D: {Name: "psk_key_exchange_modes", Recommended: true, Deprecated: false},
+	0x002F: {Name: "certificate_authorities", Recommended: true, Deprecated: false},
+	0x0030: {Name: "oid_filters", Recommended: true, Deprecated: false},
+	0x0031: {Name: "post_handshake_auth", Recommended: true, Deprecated: false},
+	0x0032: {Name: "signature_algorithms_cert", Recommended: true, Deprecated: false},
+	0x0033: {Name: "key_share", Recommended: true, Deprecated: false},
+	0x0034: {Name: "transparency_info", Recommended: true, Deprecated: false},
+	0x0035: {Name: "connection_id_deprecated", Recommended: false, Deprecated: true},
+	0x0036: {Name: "connection_id", Recommended: true, Deprecated: false},
+	0x0037: {Name: "external_id_hash", Recommended: false, Deprecated: false},
+	0x0038: {Name: "external_session_id", Recommended: false, Deprecated: false},
+	0x0039: {Name: "quic_transport_parameters", Recommended: true, Deprecated: false},
+	0x003A: {Name: "ticket_request", Recommended: true, Deprecated: false},
+	0x003B: {Name: "dnssec_chain", Recommended: false, Deprecated: false},
+	0x003C: {Name: "sequence_number_encryption_algorithms", Recommended: false, Deprecated: false},
+	0x003D: {Name: "rrc", Recommended: false, Deprecated: false},
+	0x003E: {Name: "tls_flags", Recommended: false, Deprecated: false},
+	0x3374: {Name: "next_protocol_negotiation", Recommended: false, Deprecated: false},
+	0x4469: {Name: "application_settings_old", Recommended: false, Deprecated: false},
+	0x44CD: {Name: "application_settings", Recommended: false, Deprecated: false},
+	0x754F: {Name: "channel_id_old", Recommended: false, Deprecated: false},
+	0x7550: {Name: "channel_id", Recommended: false, Deprecated: false},
+	0xFD00: {Name: "ech_outer_extensions", Recommended: false, Deprecated: false},
+	0xFE0D: {Name: "encrypted_client_hello", Recommended: false, Deprecated: false},
+	0xFF01: {Name: "renegotiation_info", Recommended: true, Deprecated: false},
+	0xFFCE: {Name: "encrypted_server_name", Recommended: false, Deprecated: false},
 }
-*/
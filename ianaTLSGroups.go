@@ -0,0 +1,67 @@
+// Code generated by ianagen from the IANA registry CSVs; DO NOT EDIT.
+
+package dactyloscopy
+
+// ianaSupportedGroups is the TLS Supported Groups registry.
+// Deprecated is set where the registry marks the value as discouraged (D)
+var ianaSupportedGroups = map[uint16]IanaEntry{
+	0x0001: {Name: "sect163k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0002: {Name: "sect163r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0003: {Name: "sect163r2", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0004: {Name: "sect193r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0005: {Name: "sect193r2", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0006: {Name: "sect233k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0007: {Name: "sect233r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0008: {Name: "sect239k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0009: {Name: "sect283k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000A: {Name: "sect283r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000B: {Name: "sect409k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000C: {Name: "sect409r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000D: {Name: "sect571k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000E: {Name: "sect571r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000F: {Name: "secp160k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0010: {Name: "secp160r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0011: {Name: "secp160r2", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0012: {Name: "secp192k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0013: {Name: "secp192r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0014: {Name: "secp224k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0015: {Name: "secp224r1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0016: {Name: "secp256k1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0017: {Name: "secp256r1", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0018: {Name: "secp384r1", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0019: {Name: "secp521r1", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x001A: {Name: "brainpoolP256r1", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x001B: {Name: "brainpoolP384r1", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x001C: {Name: "brainpoolP512r1", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x001D: {Name: "x25519", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x001E: {Name: "x448", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x001F: {Name: "brainpoolP256r1tls13", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0020: {Name: "brainpoolP384r1tls13", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0021: {Name: "brainpoolP512r1tls13", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0022: {Name: "GC256A", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0023: {Name: "GC256B", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0024: {Name: "GC256C", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0025: {Name: "GC256D", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0026: {Name: "GC512A", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0027: {Name: "GC512B", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0028: {Name: "GC512C", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0029: {Name: "curveSM2", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0100: {Name: "ffdhe2048", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0101: {Name: "ffdhe3072", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0102: {Name: "ffdhe4096", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0103: {Name: "ffdhe6144", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0104: {Name: "ffdhe8192", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0200: {Name: "MLKEM512", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0201: {Name: "MLKEM768", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0202: {Name: "MLKEM1024", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x11EB: {Name: "SecP256r1MLKEM768", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x11EC: {Name: "X25519MLKEM768", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x11ED: {Name: "SecP384r1MLKEM1024", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x11EE: {Name: "curveSM2MLKEM768", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x6399: {Name: "X25519Kyber768Draft00", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x639A: {Name: "SecP256r1Kyber768Draft00", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xFE30: {Name: "X25519Kyber512Draft00", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xFE31: {Name: "X25519Kyber768Draft00Old", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xFF01: {Name: "arbitrary_explicit_prime_curves", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xFF02: {Name: "arbitrary_explicit_char2_curves", DTLSOK: true, Recommended: false, Deprecated: true},
+}
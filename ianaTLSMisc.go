@@ -0,0 +1,38 @@
+package dactyloscopy
+
+// ianaECPointFormats is the EC Point Format registry, the compressed formats
+// are deprecated by RFC 8422
+var ianaECPointFormats = map[uint8]IanaEntry{
+	0: {Name: "uncompressed", DTLSOK: true, Recommended: true, Deprecated: false},
+	1: {Name: "ansiX962_compressed_prime", DTLSOK: true, Recommended: false, Deprecated: true},
+	2: {Name: "ansiX962_compressed_char2", DTLSOK: true, Recommended: false, Deprecated: true},
+}
+
+// ianaPSKKeyExchangeModes is the TLS PskKeyExchangeMode registry
+var ianaPSKKeyExchangeModes = map[uint8]IanaEntry{
+	0: {Name: "psk_ke", DTLSOK: true, Recommended: true, Deprecated: false},
+	1: {Name: "psk_dhe_ke", DTLSOK: true, Recommended: true, Deprecated: false},
+}
+
+// ianaCompressionMethods is the TLS Compression Method Identifiers registry.
+// Anything other than null is forbidden in TLS 1.3 and considered harmful
+// since CRIME
+var ianaCompressionMethods = map[uint8]IanaEntry{
+	0:  {Name: "null", DTLSOK: true, Recommended: true, Deprecated: false},
+	1:  {Name: "DEFLATE", DTLSOK: true, Recommended: false, Deprecated: true},
+	64: {Name: "LZS", DTLSOK: true, Recommended: false, Deprecated: true},
+}
+
+// tlsVersions isn't an IANA registry as such, but collects the protocol
+// version numbers defined by the various TLS/DTLS RFCs.  SSL 3.0, TLS 1.0 and
+// TLS 1.1 are deprecated by RFC 7568 and RFC 8996
+var tlsVersions = map[uint16]IanaEntry{
+	0x0300: {Name: "SSL 3.0", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0301: {Name: "TLS 1.0", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0302: {Name: "TLS 1.1", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0303: {Name: "TLS 1.2", DTLSOK: false, Recommended: true, Deprecated: false},
+	0x0304: {Name: "TLS 1.3", DTLSOK: false, Recommended: true, Deprecated: false},
+	0xFEFF: {Name: "DTLS 1.0", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xFEFD: {Name: "DTLS 1.2", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xFEFC: {Name: "DTLS 1.3", DTLSOK: true, Recommended: true, Deprecated: false},
+}
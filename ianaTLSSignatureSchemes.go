@@ -0,0 +1,45 @@
+// Code generated by ianagen from the IANA registry CSVs; DO NOT EDIT.
+
+package dactyloscopy
+
+// ianaSignatureSchemes is the TLS SignatureScheme registry.
+// Deprecated is set where the registry marks the value as discouraged (D)
+var ianaSignatureSchemes = map[uint16]IanaEntry{
+	0x0201: {Name: "rsa_pkcs1_sha1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0203: {Name: "ecdsa_sha1", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0401: {Name: "rsa_pkcs1_sha256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0403: {Name: "ecdsa_secp256r1_sha256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0420: {Name: "rsa_pkcs1_sha256_legacy", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0501: {Name: "rsa_pkcs1_sha384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0503: {Name: "ecdsa_secp384r1_sha384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0520: {Name: "rsa_pkcs1_sha384_legacy", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0601: {Name: "rsa_pkcs1_sha512", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0603: {Name: "ecdsa_secp521r1_sha512", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0620: {Name: "rsa_pkcs1_sha512_legacy", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0704: {Name: "eccsi_sha256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0705: {Name: "iso_ibs1", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0706: {Name: "iso_ibs2", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0707: {Name: "iso_chinese_ibs", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0708: {Name: "sm2sig_sm3", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0709: {Name: "gostr34102012_256a", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x070A: {Name: "gostr34102012_256b", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x070B: {Name: "gostr34102012_256c", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x070C: {Name: "gostr34102012_256d", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x070D: {Name: "gostr34102012_512a", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x070E: {Name: "gostr34102012_512b", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x070F: {Name: "gostr34102012_512c", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0804: {Name: "rsa_pss_rsae_sha256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0805: {Name: "rsa_pss_rsae_sha384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0806: {Name: "rsa_pss_rsae_sha512", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0807: {Name: "ed25519", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0808: {Name: "ed448", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x0809: {Name: "rsa_pss_pss_sha256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x080A: {Name: "rsa_pss_pss_sha384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x080B: {Name: "rsa_pss_pss_sha512", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x081A: {Name: "ecdsa_brainpoolP256r1tls13_sha256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x081B: {Name: "ecdsa_brainpoolP384r1tls13_sha384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x081C: {Name: "ecdsa_brainpoolP512r1tls13_sha512", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0904: {Name: "mldsa44", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0905: {Name: "mldsa65", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0906: {Name: "mldsa87", DTLSOK: true, Recommended: false, Deprecated: false},
+}
@@ -1,371 +1,360 @@
-package dactyloscopy
-
-//lint:ignore U1000 not in use yet
-type CiphersuiteInfo struct {
-	Name        string
-	DTLSOK      bool
-	Recommended bool
-}
+// Code generated by ianagen from the IANA registry CSVs; DO NOT EDIT.
 
-//lint:ignore U1000 not in use yet
-func GetIanaEntry(suiteID [2]uint16) CiphersuiteInfo {
-	return ianaCiphersuites[suiteID]
-}
+package dactyloscopy
 
-//lint:ignore U1000 not in use yet
-var ianaCiphersuites = map[[2]uint16]CiphersuiteInfo{
-	{0x00, 0x00}: {Name: "TLS_NULL_WITH_NULL_NULL", DTLSOK: true, Recommended: false},
-	{0x00, 0x01}: {Name: "TLS_RSA_WITH_NULL_MD5", DTLSOK: true, Recommended: false},
-	{0x00, 0x02}: {Name: "TLS_RSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x03}: {Name: "TLS_RSA_EXPORT_WITH_RC4_40_MD5", DTLSOK: false, Recommended: false},
-	{0x00, 0x04}: {Name: "TLS_RSA_WITH_RC4_128_MD5", DTLSOK: false, Recommended: false},
-	{0x00, 0x05}: {Name: "TLS_RSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0x00, 0x06}: {Name: "TLS_RSA_EXPORT_WITH_RC2_CBC_40_MD5", DTLSOK: true, Recommended: false},
-	{0x00, 0x07}: {Name: "TLS_RSA_WITH_IDEA_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x08}: {Name: "TLS_RSA_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x09}: {Name: "TLS_RSA_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x0A}: {Name: "TLS_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x0B}: {Name: "TLS_DH_DSS_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x0C}: {Name: "TLS_DH_DSS_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x0D}: {Name: "TLS_DH_DSS_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x0E}: {Name: "TLS_DH_RSA_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x0F}: {Name: "TLS_DH_RSA_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x10}: {Name: "TLS_DH_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x11}: {Name: "TLS_DHE_DSS_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x12}: {Name: "TLS_DHE_DSS_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x13}: {Name: "TLS_DHE_DSS_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x14}: {Name: "TLS_DHE_RSA_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x15}: {Name: "TLS_DHE_RSA_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x16}: {Name: "TLS_DHE_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x17}: {Name: "TLS_DH_anon_EXPORT_WITH_RC4_40_MD5", DTLSOK: false, Recommended: false},
-	{0x00, 0x18}: {Name: "TLS_DH_anon_WITH_RC4_128_MD5", DTLSOK: false, Recommended: false},
-	{0x00, 0x19}: {Name: "TLS_DH_anon_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x1A}: {Name: "TLS_DH_anon_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x1B}: {Name: "TLS_DH_anon_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x1E}: {Name: "TLS_KRB5_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x1F}: {Name: "TLS_KRB5_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x20}: {Name: "TLS_KRB5_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0x00, 0x21}: {Name: "TLS_KRB5_WITH_IDEA_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x22}: {Name: "TLS_KRB5_WITH_DES_CBC_MD5", DTLSOK: true, Recommended: false},
-	{0x00, 0x23}: {Name: "TLS_KRB5_WITH_3DES_EDE_CBC_MD5", DTLSOK: true, Recommended: false},
-	{0x00, 0x24}: {Name: "TLS_KRB5_WITH_RC4_128_MD5", DTLSOK: false, Recommended: false},
-	{0x00, 0x25}: {Name: "TLS_KRB5_WITH_IDEA_CBC_MD5", DTLSOK: true, Recommended: false},
-	{0x00, 0x26}: {Name: "TLS_KRB5_EXPORT_WITH_DES_CBC_40_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x27}: {Name: "TLS_KRB5_EXPORT_WITH_RC2_CBC_40_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x28}: {Name: "TLS_KRB5_EXPORT_WITH_RC4_40_SHA", DTLSOK: false, Recommended: false},
-	{0x00, 0x29}: {Name: "TLS_KRB5_EXPORT_WITH_DES_CBC_40_MD5", DTLSOK: true, Recommended: false},
-	{0x00, 0x2A}: {Name: "TLS_KRB5_EXPORT_WITH_RC2_CBC_40_MD5", DTLSOK: true, Recommended: false},
-	{0x00, 0x2B}: {Name: "TLS_KRB5_EXPORT_WITH_RC4_40_MD5", DTLSOK: false, Recommended: false},
-	{0x00, 0x2C}: {Name: "TLS_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x2D}: {Name: "TLS_DHE_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x2E}: {Name: "TLS_RSA_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x2F}: {Name: "TLS_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x30}: {Name: "TLS_DH_DSS_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x31}: {Name: "TLS_DH_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x32}: {Name: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x33}: {Name: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x34}: {Name: "TLS_DH_anon_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x35}: {Name: "TLS_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x36}: {Name: "TLS_DH_DSS_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x37}: {Name: "TLS_DH_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x38}: {Name: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x39}: {Name: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x3A}: {Name: "TLS_DH_anon_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x3B}: {Name: "TLS_RSA_WITH_NULL_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x3C}: {Name: "TLS_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x3D}: {Name: "TLS_RSA_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x3E}: {Name: "TLS_DH_DSS_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x3F}: {Name: "TLS_DH_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x40}: {Name: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x41}: {Name: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x42}: {Name: "TLS_DH_DSS_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x41}: {Name: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x42}: {Name: "TLS_DH_DSS_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x43}: {Name: "TLS_DH_RSA_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x44}: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x45}: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x46}: {Name: "TLS_DH_anon_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x67}: {Name: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x68}: {Name: "TLS_DH_DSS_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x69}: {Name: "TLS_DH_RSA_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x6A}: {Name: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x6B}: {Name: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x6C}: {Name: "TLS_DH_anon_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x6D}: {Name: "TLS_DH_anon_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x84}: {Name: "TLS_RSA_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x85}: {Name: "TLS_DH_DSS_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x86}: {Name: "TLS_DH_RSA_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x87}: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x88}: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x89}: {Name: "TLS_DH_anon_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x8A}: {Name: "TLS_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0x00, 0x8B}: {Name: "TLS_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x8C}: {Name: "TLS_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x8D}: {Name: "TLS_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x8E}: {Name: "TLS_DHE_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0x00, 0x8F}: {Name: "TLS_DHE_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x90}: {Name: "TLS_DHE_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x91}: {Name: "TLS_DHE_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x92}: {Name: "TLS_RSA_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0x00, 0x93}: {Name: "TLS_RSA_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x94}: {Name: "TLS_RSA_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x95}: {Name: "TLS_RSA_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x96}: {Name: "TLS_RSA_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x97}: {Name: "TLS_DH_DSS_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x98}: {Name: "TLS_DH_RSA_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x99}: {Name: "TLS_DHE_DSS_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x9A}: {Name: "TLS_DHE_RSA_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x9B}: {Name: "TLS_DH_anon_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0x00, 0x9C}: {Name: "TLS_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0x9D}: {Name: "TLS_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0x9E}: {Name: "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true},
-	{0x00, 0x9F}: {Name: "TLS_DHE_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true},
-	{0x00, 0xA0}: {Name: "TLS_DH_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xA1}: {Name: "TLS_DH_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xA2}: {Name: "TLS_DHE_DSS_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xA3}: {Name: "TLS_DHE_DSS_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xA4}: {Name: "TLS_DH_DSS_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xA5}: {Name: "TLS_DH_DSS_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xA6}: {Name: "TLS_DH_anon_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xA7}: {Name: "TLS_DH_anon_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xA8}: {Name: "TLS_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xA9}: {Name: "TLS_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xAA}: {Name: "TLS_DHE_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true},
-	{0x00, 0xAB}: {Name: "TLS_DHE_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true},
-	{0x00, 0xAC}: {Name: "TLS_RSA_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xAD}: {Name: "TLS_RSA_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xAE}: {Name: "TLS_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xAF}: {Name: "TLS_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xB0}: {Name: "TLS_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xB1}: {Name: "TLS_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xB2}: {Name: "TLS_DHE_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xB3}: {Name: "TLS_DHE_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xB4}: {Name: "TLS_DHE_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xB5}: {Name: "TLS_DHE_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xB6}: {Name: "TLS_RSA_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xB7}: {Name: "TLS_RSA_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xB8}: {Name: "TLS_RSA_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xB9}: {Name: "TLS_RSA_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false},
-	{0x00, 0xBA}: {Name: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xBB}: {Name: "TLS_DH_DSS_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xBC}: {Name: "TLS_DH_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xBD}: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xBE}: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xBF}: {Name: "TLS_DH_anon_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xC0}: {Name: "TLS_RSA_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xC1}: {Name: "TLS_DH_DSS_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xC2}: {Name: "TLS_DH_RSA_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xC3}: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xC4}: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xC5}: {Name: "TLS_DH_anon_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0x00, 0xC6}: {Name: "TLS_SM4_GCM_SM3", DTLSOK: false, Recommended: false},
-	{0x00, 0xC7}: {Name: "TLS_SM4_CCM_SM3", DTLSOK: false, Recommended: false},
-	{0x00, 0xFF}: {Name: "TLS_EMPTY_RENEGOTIATION_INFO_SCSV", DTLSOK: true, Recommended: false},
-	{0x13, 0x01}: {Name: "TLS_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true},
-	{0x13, 0x02}: {Name: "TLS_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true},
-	{0x13, 0x03}: {Name: "TLS_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true},
-	{0x13, 0x04}: {Name: "TLS_AES_128_CCM_SHA256", DTLSOK: true, Recommended: true},
-	{0x13, 0x05}: {Name: "TLS_AES_128_CCM_8_SHA256", DTLSOK: true, Recommended: false},
-	{0x13, 0x06}: {Name: "TLS_AEGIS_256_SHA384", DTLSOK: true, Recommended: false},
-	{0x13, 0x07}: {Name: "TLS_AEGIS_128L_SHA256", DTLSOK: true, Recommended: false},
-	{0x56, 0x00}: {Name: "TLS_FALLBACK_SCSV", DTLSOK: true, Recommended: false},
-	{0xC0, 0x01}: {Name: "TLS_ECDH_ECDSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x02}: {Name: "TLS_ECDH_ECDSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0xC0, 0x03}: {Name: "TLS_ECDH_ECDSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x04}: {Name: "TLS_ECDH_ECDSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x05}: {Name: "TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x06}: {Name: "TLS_ECDHE_ECDSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x07}: {Name: "TLS_ECDHE_ECDSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0xC0, 0x08}: {Name: "TLS_ECDHE_ECDSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x09}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x0A}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x0B}: {Name: "TLS_ECDH_RSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x0C}: {Name: "TLS_ECDH_RSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0xC0, 0x0D}: {Name: "TLS_ECDH_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x0E}: {Name: "TLS_ECDH_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x0F}: {Name: "TLS_ECDH_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x10}: {Name: "TLS_ECDHE_RSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x11}: {Name: "TLS_ECDHE_RSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0xC0, 0x12}: {Name: "TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x13}: {Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x14}: {Name: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x15}: {Name: "TLS_ECDH_anon_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x16}: {Name: "TLS_ECDH_anon_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0xC0, 0x17}: {Name: "TLS_ECDH_anon_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x18}: {Name: "TLS_ECDH_anon_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x19}: {Name: "TLS_ECDH_anon_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x1A}: {Name: "TLS_SRP_SHA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x1B}: {Name: "TLS_SRP_SHA_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x1C}: {Name: "TLS_SRP_SHA_DSS_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x1D}: {Name: "TLS_SRP_SHA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x1E}: {Name: "TLS_SRP_SHA_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x1F}: {Name: "TLS_SRP_SHA_DSS_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x20}: {Name: "TLS_SRP_SHA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x21}: {Name: "TLS_SRP_SHA_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x22}: {Name: "TLS_SRP_SHA_DSS_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x23}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x24}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x25}: {Name: "TLS_ECDH_ECDSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x26}: {Name: "TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x27}: {Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x28}: {Name: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x29}: {Name: "TLS_ECDH_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x2A}: {Name: "TLS_ECDH_RSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x2B}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true},
-	{0xC0, 0x2C}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true},
-	{0xC0, 0x2D}: {Name: "TLS_ECDH_ECDSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x2E}: {Name: "TLS_ECDH_ECDSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x2F}: {Name: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true},
-	{0xC0, 0x30}: {Name: "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true},
-	{0xC0, 0x31}: {Name: "TLS_ECDH_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x32}: {Name: "TLS_ECDH_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x33}: {Name: "TLS_ECDHE_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false},
-	{0xC0, 0x34}: {Name: "TLS_ECDHE_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x35}: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x36}: {Name: "TLS_ECDHE_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x37}: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x38}: {Name: "TLS_ECDHE_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x39}: {Name: "TLS_ECDHE_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false},
-	{0xC0, 0x3A}: {Name: "TLS_ECDHE_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x3B}: {Name: "TLS_ECDHE_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x3C}: {Name: "TLS_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x3D}: {Name: "TLS_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x3E}: {Name: "TLS_DH_DSS_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x3F}: {Name: "TLS_DH_DSS_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x40}: {Name: "TLS_DH_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x41}: {Name: "TLS_DH_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x42}: {Name: "TLS_DHE_DSS_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x43}: {Name: "TLS_DHE_DSS_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x44}: {Name: "TLS_DHE_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x45}: {Name: "TLS_DHE_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x46}: {Name: "TLS_DH_anon_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x47}: {Name: "TLS_DH_anon_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x48}: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x49}: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x4A}: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x4B}: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x4C}: {Name: "TLS_ECDHE_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x4D}: {Name: "TLS_ECDHE_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x4E}: {Name: "TLS_ECDH_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x4F}: {Name: "TLS_ECDH_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x50}: {Name: "TLS_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x51}: {Name: "TLS_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x52}: {Name: "TLS_DHE_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x53}: {Name: "TLS_DHE_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x54}: {Name: "TLS_DH_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x55}: {Name: "TLS_DH_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x56}: {Name: "TLS_DHE_DSS_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x57}: {Name: "TLS_DHE_DSS_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x58}: {Name: "TLS_DH_DSS_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x59}: {Name: "TLS_DH_DSS_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x5A}: {Name: "TLS_DH_anon_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x5B}: {Name: "TLS_DH_anon_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x5C}: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x5D}: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x5E}: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x5F}: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x60}: {Name: "TLS_ECDHE_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x61}: {Name: "TLS_ECDHE_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x62}: {Name: "TLS_ECDH_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x63}: {Name: "TLS_ECDH_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x64}: {Name: "TLS_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x65}: {Name: "TLS_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x66}: {Name: "TLS_DHE_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x67}: {Name: "TLS_DHE_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x68}: {Name: "TLS_RSA_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x69}: {Name: "TLS_RSA_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x6A}: {Name: "TLS_PSK_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x6B}: {Name: "TLS_PSK_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x6C}: {Name: "TLS_DHE_PSK_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x6D}: {Name: "TLS_DHE_PSK_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x6E}: {Name: "TLS_RSA_PSK_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x6F}: {Name: "TLS_RSA_PSK_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x70}: {Name: "TLS_ECDHE_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x71}: {Name: "TLS_ECDHE_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x72}: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x73}: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x74}: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x75}: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x76}: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x77}: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x78}: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x79}: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x7A}: {Name: "TLS_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x7B}: {Name: "TLS_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x7C}: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x7D}: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x7E}: {Name: "TLS_DH_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x7F}: {Name: "TLS_DH_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x80}: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x81}: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x82}: {Name: "TLS_DH_DSS_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x83}: {Name: "TLS_DH_DSS_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x84}: {Name: "TLS_DH_anon_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x85}: {Name: "TLS_DH_anon_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x86}: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x87}: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x88}: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x89}: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x8A}: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x8B}: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x8C}: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x8D}: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x8E}: {Name: "TLS_PSK_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x8F}: {Name: "TLS_PSK_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x90}: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x91}: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x92}: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x93}: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x94}: {Name: "TLS_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x95}: {Name: "TLS_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x96}: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x97}: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x98}: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x99}: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x9A}: {Name: "TLS_ECDHE_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0x9B}: {Name: "TLS_ECDHE_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0x9C}: {Name: "TLS_RSA_WITH_AES_128_CCM", DTLSOK: true, Recommended: false},
-	{0xC0, 0x9D}: {Name: "TLS_RSA_WITH_AES_256_CCM", DTLSOK: true, Recommended: false},
-	{0xC0, 0x9E}: {Name: "TLS_DHE_RSA_WITH_AES_128_CCM", DTLSOK: true, Recommended: true},
-	{0xC0, 0x9F}: {Name: "TLS_DHE_RSA_WITH_AES_256_CCM", DTLSOK: true, Recommended: true},
-	{0xC0, 0xA0}: {Name: "TLS_RSA_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xA1}: {Name: "TLS_RSA_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xA2}: {Name: "TLS_DHE_RSA_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xA3}: {Name: "TLS_DHE_RSA_WITH_AES_256_CCM_8", DTLSOK: false, Recommended: false},
-	{0xC0, 0xA4}: {Name: "TLS_PSK_WITH_AES_128_CCM", DTLSOK: true, Recommended: false},
-	{0xC0, 0xA5}: {Name: "TLS_PSK_WITH_AES_256_CCM", DTLSOK: true, Recommended: false},
-	{0xC0, 0xA6}: {Name: "TLS_DHE_PSK_WITH_AES_128_CCM", DTLSOK: true, Recommended: true},
-	{0xC0, 0xA7}: {Name: "TLS_DHE_PSK_WITH_AES_256_CCM", DTLSOK: true, Recommended: true},
-	{0xC0, 0xA8}: {Name: "TLS_PSK_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xA9}: {Name: "TLS_PSK_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xAA}: {Name: "TLS_PSK_DHE_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xAB}: {Name: "TLS_PSK_DHE_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xAC}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CCM", DTLSOK: true, Recommended: false},
-	{0xC0, 0xAD}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CCM", DTLSOK: true, Recommended: false},
-	{0xC0, 0xAE}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xAF}: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false},
-	{0xC0, 0xB0}: {Name: "TLS_ECCPWD_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0xB1}: {Name: "TLS_ECCPWD_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0xB2}: {Name: "TLS_ECCPWD_WITH_AES_128_CCM_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0xB3}: {Name: "TLS_ECCPWD_WITH_AES_256_CCM_SHA384", DTLSOK: true, Recommended: false},
-	{0xC0, 0xB4}: {Name: "TLS_SHA256_SHA256", DTLSOK: true, Recommended: false},
-	{0xC0, 0xB5}: {Name: "TLS_SHA384_SHA384", DTLSOK: true, Recommended: false},
-	{0xC1, 0x00}: {Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_CTR_OMAC", DTLSOK: false, Recommended: false},
-	{0xC1, 0x01}: {Name: "TLS_GOSTR341112_256_WITH_MAGMA_CTR_OMAC", DTLSOK: false, Recommended: false},
-	{0xC1, 0x02}: {Name: "TLS_GOSTR341112_256_WITH_28147_CNT_IMIT", DTLSOK: false, Recommended: false},
-	{0xC1, 0x03}: {Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_MGM_L", DTLSOK: false, Recommended: false},
-	{0xC1, 0x04}: {Name: "TLS_GOSTR341112_256_WITH_MAGMA_MGM_L", DTLSOK: false, Recommended: false},
-	{0xC1, 0x05}: {Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_MGM_S", DTLSOK: false, Recommended: false},
-	{0xC1, 0x06}: {Name: "TLS_GOSTR341112_256_WITH_MAGMA_MGM_S", DTLSOK: false, Recommended: false},
-	{0xCC, 0xA8}: {Name: "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true},
-	{0xCC, 0xA9}: {Name: "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true},
-	{0xCC, 0xAA}: {Name: "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true},
-	{0xCC, 0xAB}: {Name: "TLS_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: false},
-	{0xCC, 0xAC}: {Name: "TLS_ECDHE_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true},
-	{0xCC, 0xAD}: {Name: "TLS_DHE_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true},
-	{0xCC, 0xAE}: {Name: "TLS_RSA_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: false},
-	{0xD0, 0x01}: {Name: "TLS_ECDHE_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true},
-	{0xD0, 0x02}: {Name: "TLS_ECDHE_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true},
-	{0xD0, 0x03}: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CCM_8_SHA256", DTLSOK: true, Recommended: false},
-	{0xD0, 0x05}: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CCM_SHA256", DTLSOK: true, Recommended: true},
+// ianaCiphersuites is the TLS Cipher Suites registry.
+// Deprecated is set where the registry marks the value as discouraged (D)
+var ianaCiphersuites = map[uint16]IanaEntry{
+	0x0000: {Name: "TLS_NULL_WITH_NULL_NULL", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0001: {Name: "TLS_RSA_WITH_NULL_MD5", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0002: {Name: "TLS_RSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0003: {Name: "TLS_RSA_EXPORT_WITH_RC4_40_MD5", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0004: {Name: "TLS_RSA_WITH_RC4_128_MD5", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0005: {Name: "TLS_RSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0006: {Name: "TLS_RSA_EXPORT_WITH_RC2_CBC_40_MD5", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0007: {Name: "TLS_RSA_WITH_IDEA_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0008: {Name: "TLS_RSA_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0009: {Name: "TLS_RSA_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000A: {Name: "TLS_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x000B: {Name: "TLS_DH_DSS_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000C: {Name: "TLS_DH_DSS_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000D: {Name: "TLS_DH_DSS_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x000E: {Name: "TLS_DH_RSA_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x000F: {Name: "TLS_DH_RSA_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0010: {Name: "TLS_DH_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0011: {Name: "TLS_DHE_DSS_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0012: {Name: "TLS_DHE_DSS_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0013: {Name: "TLS_DHE_DSS_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0014: {Name: "TLS_DHE_RSA_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0015: {Name: "TLS_DHE_RSA_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0016: {Name: "TLS_DHE_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0017: {Name: "TLS_DH_anon_EXPORT_WITH_RC4_40_MD5", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0018: {Name: "TLS_DH_anon_WITH_RC4_128_MD5", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0019: {Name: "TLS_DH_anon_EXPORT_WITH_DES40_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x001A: {Name: "TLS_DH_anon_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x001B: {Name: "TLS_DH_anon_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x001E: {Name: "TLS_KRB5_WITH_DES_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x001F: {Name: "TLS_KRB5_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0020: {Name: "TLS_KRB5_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0021: {Name: "TLS_KRB5_WITH_IDEA_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0022: {Name: "TLS_KRB5_WITH_DES_CBC_MD5", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0023: {Name: "TLS_KRB5_WITH_3DES_EDE_CBC_MD5", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0024: {Name: "TLS_KRB5_WITH_RC4_128_MD5", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0025: {Name: "TLS_KRB5_WITH_IDEA_CBC_MD5", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0026: {Name: "TLS_KRB5_EXPORT_WITH_DES_CBC_40_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0027: {Name: "TLS_KRB5_EXPORT_WITH_RC2_CBC_40_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x0028: {Name: "TLS_KRB5_EXPORT_WITH_RC4_40_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0029: {Name: "TLS_KRB5_EXPORT_WITH_DES_CBC_40_MD5", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x002A: {Name: "TLS_KRB5_EXPORT_WITH_RC2_CBC_40_MD5", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x002B: {Name: "TLS_KRB5_EXPORT_WITH_RC4_40_MD5", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x002C: {Name: "TLS_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x002D: {Name: "TLS_DHE_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x002E: {Name: "TLS_RSA_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x002F: {Name: "TLS_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0030: {Name: "TLS_DH_DSS_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0031: {Name: "TLS_DH_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0032: {Name: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0033: {Name: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0034: {Name: "TLS_DH_anon_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0035: {Name: "TLS_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0036: {Name: "TLS_DH_DSS_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0037: {Name: "TLS_DH_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0038: {Name: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0039: {Name: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x003A: {Name: "TLS_DH_anon_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x003B: {Name: "TLS_RSA_WITH_NULL_SHA256", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x003C: {Name: "TLS_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x003D: {Name: "TLS_RSA_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x003E: {Name: "TLS_DH_DSS_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x003F: {Name: "TLS_DH_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0040: {Name: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0041: {Name: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0042: {Name: "TLS_DH_DSS_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0043: {Name: "TLS_DH_RSA_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0044: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0045: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0046: {Name: "TLS_DH_anon_WITH_CAMELLIA_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0067: {Name: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0068: {Name: "TLS_DH_DSS_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0069: {Name: "TLS_DH_RSA_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x006A: {Name: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x006B: {Name: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x006C: {Name: "TLS_DH_anon_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x006D: {Name: "TLS_DH_anon_WITH_AES_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0084: {Name: "TLS_RSA_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0085: {Name: "TLS_DH_DSS_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0086: {Name: "TLS_DH_RSA_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0087: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0088: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0089: {Name: "TLS_DH_anon_WITH_CAMELLIA_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x008A: {Name: "TLS_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x008B: {Name: "TLS_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x008C: {Name: "TLS_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x008D: {Name: "TLS_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x008E: {Name: "TLS_DHE_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x008F: {Name: "TLS_DHE_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0090: {Name: "TLS_DHE_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0091: {Name: "TLS_DHE_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0092: {Name: "TLS_RSA_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0x0093: {Name: "TLS_RSA_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0094: {Name: "TLS_RSA_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0095: {Name: "TLS_RSA_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0096: {Name: "TLS_RSA_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0097: {Name: "TLS_DH_DSS_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0098: {Name: "TLS_DH_RSA_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x0099: {Name: "TLS_DHE_DSS_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x009A: {Name: "TLS_DHE_RSA_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x009B: {Name: "TLS_DH_anon_WITH_SEED_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x009C: {Name: "TLS_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x009D: {Name: "TLS_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x009E: {Name: "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x009F: {Name: "TLS_DHE_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x00A0: {Name: "TLS_DH_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A1: {Name: "TLS_DH_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A2: {Name: "TLS_DHE_DSS_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A3: {Name: "TLS_DHE_DSS_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A4: {Name: "TLS_DH_DSS_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A5: {Name: "TLS_DH_DSS_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A6: {Name: "TLS_DH_anon_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A7: {Name: "TLS_DH_anon_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A8: {Name: "TLS_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00A9: {Name: "TLS_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00AA: {Name: "TLS_DHE_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x00AB: {Name: "TLS_DHE_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x00AC: {Name: "TLS_RSA_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00AD: {Name: "TLS_RSA_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00AE: {Name: "TLS_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00AF: {Name: "TLS_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00B0: {Name: "TLS_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x00B1: {Name: "TLS_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x00B2: {Name: "TLS_DHE_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00B3: {Name: "TLS_DHE_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00B4: {Name: "TLS_DHE_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x00B5: {Name: "TLS_DHE_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x00B6: {Name: "TLS_RSA_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00B7: {Name: "TLS_RSA_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00B8: {Name: "TLS_RSA_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x00B9: {Name: "TLS_RSA_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false, Deprecated: true},
+	0x00BA: {Name: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00BB: {Name: "TLS_DH_DSS_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00BC: {Name: "TLS_DH_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00BD: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00BE: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00BF: {Name: "TLS_DH_anon_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00C0: {Name: "TLS_RSA_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00C1: {Name: "TLS_DH_DSS_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00C2: {Name: "TLS_DH_RSA_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00C3: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00C4: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00C5: {Name: "TLS_DH_anon_WITH_CAMELLIA_256_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x00C6: {Name: "TLS_SM4_GCM_SM3", DTLSOK: false, Recommended: false, Deprecated: false},
+	0x00C7: {Name: "TLS_SM4_CCM_SM3", DTLSOK: false, Recommended: false, Deprecated: false},
+	0x00FF: {Name: "TLS_EMPTY_RENEGOTIATION_INFO_SCSV", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x1301: {Name: "TLS_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x1302: {Name: "TLS_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x1303: {Name: "TLS_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x1304: {Name: "TLS_AES_128_CCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0x1305: {Name: "TLS_AES_128_CCM_8_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x1306: {Name: "TLS_AEGIS_256_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x1307: {Name: "TLS_AEGIS_128L_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0x5600: {Name: "TLS_FALLBACK_SCSV", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC001: {Name: "TLS_ECDH_ECDSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC002: {Name: "TLS_ECDH_ECDSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xC003: {Name: "TLS_ECDH_ECDSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC004: {Name: "TLS_ECDH_ECDSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC005: {Name: "TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC006: {Name: "TLS_ECDHE_ECDSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC007: {Name: "TLS_ECDHE_ECDSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xC008: {Name: "TLS_ECDHE_ECDSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC009: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC00A: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC00B: {Name: "TLS_ECDH_RSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC00C: {Name: "TLS_ECDH_RSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xC00D: {Name: "TLS_ECDH_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC00E: {Name: "TLS_ECDH_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC00F: {Name: "TLS_ECDH_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC010: {Name: "TLS_ECDHE_RSA_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC011: {Name: "TLS_ECDHE_RSA_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xC012: {Name: "TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC013: {Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC014: {Name: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC015: {Name: "TLS_ECDH_anon_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC016: {Name: "TLS_ECDH_anon_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xC017: {Name: "TLS_ECDH_anon_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC018: {Name: "TLS_ECDH_anon_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC019: {Name: "TLS_ECDH_anon_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC01A: {Name: "TLS_SRP_SHA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC01B: {Name: "TLS_SRP_SHA_RSA_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC01C: {Name: "TLS_SRP_SHA_DSS_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC01D: {Name: "TLS_SRP_SHA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC01E: {Name: "TLS_SRP_SHA_RSA_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC01F: {Name: "TLS_SRP_SHA_DSS_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC020: {Name: "TLS_SRP_SHA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC021: {Name: "TLS_SRP_SHA_RSA_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC022: {Name: "TLS_SRP_SHA_DSS_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC023: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC024: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC025: {Name: "TLS_ECDH_ECDSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC026: {Name: "TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC027: {Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC028: {Name: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC029: {Name: "TLS_ECDH_RSA_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC02A: {Name: "TLS_ECDH_RSA_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC02B: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC02C: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC02D: {Name: "TLS_ECDH_ECDSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC02E: {Name: "TLS_ECDH_ECDSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC02F: {Name: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC030: {Name: "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC031: {Name: "TLS_ECDH_RSA_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC032: {Name: "TLS_ECDH_RSA_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC033: {Name: "TLS_ECDHE_PSK_WITH_RC4_128_SHA", DTLSOK: false, Recommended: false, Deprecated: true},
+	0xC034: {Name: "TLS_ECDHE_PSK_WITH_3DES_EDE_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC035: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC036: {Name: "TLS_ECDHE_PSK_WITH_AES_256_CBC_SHA", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC037: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC038: {Name: "TLS_ECDHE_PSK_WITH_AES_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC039: {Name: "TLS_ECDHE_PSK_WITH_NULL_SHA", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC03A: {Name: "TLS_ECDHE_PSK_WITH_NULL_SHA256", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC03B: {Name: "TLS_ECDHE_PSK_WITH_NULL_SHA384", DTLSOK: true, Recommended: false, Deprecated: true},
+	0xC03C: {Name: "TLS_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC03D: {Name: "TLS_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC03E: {Name: "TLS_DH_DSS_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC03F: {Name: "TLS_DH_DSS_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC040: {Name: "TLS_DH_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC041: {Name: "TLS_DH_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC042: {Name: "TLS_DHE_DSS_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC043: {Name: "TLS_DHE_DSS_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC044: {Name: "TLS_DHE_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC045: {Name: "TLS_DHE_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC046: {Name: "TLS_DH_anon_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC047: {Name: "TLS_DH_anon_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC048: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC049: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC04A: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC04B: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC04C: {Name: "TLS_ECDHE_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC04D: {Name: "TLS_ECDHE_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC04E: {Name: "TLS_ECDH_RSA_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC04F: {Name: "TLS_ECDH_RSA_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC050: {Name: "TLS_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC051: {Name: "TLS_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC052: {Name: "TLS_DHE_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC053: {Name: "TLS_DHE_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC054: {Name: "TLS_DH_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC055: {Name: "TLS_DH_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC056: {Name: "TLS_DHE_DSS_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC057: {Name: "TLS_DHE_DSS_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC058: {Name: "TLS_DH_DSS_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC059: {Name: "TLS_DH_DSS_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC05A: {Name: "TLS_DH_anon_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC05B: {Name: "TLS_DH_anon_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC05C: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC05D: {Name: "TLS_ECDHE_ECDSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC05E: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC05F: {Name: "TLS_ECDH_ECDSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC060: {Name: "TLS_ECDHE_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC061: {Name: "TLS_ECDHE_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC062: {Name: "TLS_ECDH_RSA_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC063: {Name: "TLS_ECDH_RSA_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC064: {Name: "TLS_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC065: {Name: "TLS_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC066: {Name: "TLS_DHE_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC067: {Name: "TLS_DHE_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC068: {Name: "TLS_RSA_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC069: {Name: "TLS_RSA_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC06A: {Name: "TLS_PSK_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC06B: {Name: "TLS_PSK_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC06C: {Name: "TLS_DHE_PSK_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC06D: {Name: "TLS_DHE_PSK_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC06E: {Name: "TLS_RSA_PSK_WITH_ARIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC06F: {Name: "TLS_RSA_PSK_WITH_ARIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC070: {Name: "TLS_ECDHE_PSK_WITH_ARIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC071: {Name: "TLS_ECDHE_PSK_WITH_ARIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC072: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC073: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC074: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC075: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC076: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC077: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC078: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC079: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC07A: {Name: "TLS_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC07B: {Name: "TLS_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC07C: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC07D: {Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC07E: {Name: "TLS_DH_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC07F: {Name: "TLS_DH_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC080: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC081: {Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC082: {Name: "TLS_DH_DSS_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC083: {Name: "TLS_DH_DSS_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC084: {Name: "TLS_DH_anon_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC085: {Name: "TLS_DH_anon_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC086: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC087: {Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC088: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC089: {Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC08A: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC08B: {Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC08C: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC08D: {Name: "TLS_ECDH_RSA_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC08E: {Name: "TLS_PSK_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC08F: {Name: "TLS_PSK_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC090: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC091: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC092: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC093: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC094: {Name: "TLS_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC095: {Name: "TLS_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC096: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC097: {Name: "TLS_DHE_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC098: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC099: {Name: "TLS_RSA_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC09A: {Name: "TLS_ECDHE_PSK_WITH_CAMELLIA_128_CBC_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC09B: {Name: "TLS_ECDHE_PSK_WITH_CAMELLIA_256_CBC_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC09C: {Name: "TLS_RSA_WITH_AES_128_CCM", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC09D: {Name: "TLS_RSA_WITH_AES_256_CCM", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC09E: {Name: "TLS_DHE_RSA_WITH_AES_128_CCM", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC09F: {Name: "TLS_DHE_RSA_WITH_AES_256_CCM", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC0A0: {Name: "TLS_RSA_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0A1: {Name: "TLS_RSA_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0A2: {Name: "TLS_DHE_RSA_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0A3: {Name: "TLS_DHE_RSA_WITH_AES_256_CCM_8", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xC0A4: {Name: "TLS_PSK_WITH_AES_128_CCM", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0A5: {Name: "TLS_PSK_WITH_AES_256_CCM", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0A6: {Name: "TLS_DHE_PSK_WITH_AES_128_CCM", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC0A7: {Name: "TLS_DHE_PSK_WITH_AES_256_CCM", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xC0A8: {Name: "TLS_PSK_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0A9: {Name: "TLS_PSK_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0AA: {Name: "TLS_PSK_DHE_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0AB: {Name: "TLS_PSK_DHE_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0AC: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CCM", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0AD: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CCM", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0AE: {Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0AF: {Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CCM_8", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0B0: {Name: "TLS_ECCPWD_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0B1: {Name: "TLS_ECCPWD_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0B2: {Name: "TLS_ECCPWD_WITH_AES_128_CCM_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0B3: {Name: "TLS_ECCPWD_WITH_AES_256_CCM_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0B4: {Name: "TLS_SHA256_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC0B5: {Name: "TLS_SHA384_SHA384", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xC100: {Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_CTR_OMAC", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xC101: {Name: "TLS_GOSTR341112_256_WITH_MAGMA_CTR_OMAC", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xC102: {Name: "TLS_GOSTR341112_256_WITH_28147_CNT_IMIT", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xC103: {Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_MGM_L", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xC104: {Name: "TLS_GOSTR341112_256_WITH_MAGMA_MGM_L", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xC105: {Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_MGM_S", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xC106: {Name: "TLS_GOSTR341112_256_WITH_MAGMA_MGM_S", DTLSOK: false, Recommended: false, Deprecated: false},
+	0xCCA8: {Name: "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xCCA9: {Name: "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xCCAA: {Name: "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xCCAB: {Name: "TLS_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xCCAC: {Name: "TLS_ECDHE_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xCCAD: {Name: "TLS_DHE_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xCCAE: {Name: "TLS_RSA_PSK_WITH_CHACHA20_POLY1305_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xD001: {Name: "TLS_ECDHE_PSK_WITH_AES_128_GCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xD002: {Name: "TLS_ECDHE_PSK_WITH_AES_256_GCM_SHA384", DTLSOK: true, Recommended: true, Deprecated: false},
+	0xD003: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CCM_8_SHA256", DTLSOK: true, Recommended: false, Deprecated: false},
+	0xD005: {Name: "TLS_ECDHE_PSK_WITH_AES_128_CCM_SHA256", DTLSOK: true, Recommended: true, Deprecated: false},
 }
@@ -0,0 +1,64 @@
+package dactyloscopy_test
+
+import (
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIANALookups(t *testing.T) {
+	entry, ok := dactyloscopy.LookupCiphersuite(0x1301)
+	assert.True(t, ok)
+	assert.Equal(t, "TLS_AES_128_GCM_SHA256", entry.Name)
+	assert.True(t, entry.Recommended)
+	assert.Equal(t, entry, dactyloscopy.GetIanaEntry(0x1301))
+
+	entry, ok = dactyloscopy.LookupCiphersuite(0x0005)
+	assert.True(t, ok)
+	assert.True(t, entry.Deprecated, "RC4 should be deprecated")
+
+	_, ok = dactyloscopy.LookupCiphersuite(0x2a2a)
+	assert.False(t, ok)
+
+	entry, ok = dactyloscopy.LookupExtension(dactyloscopy.ExtServerName)
+	assert.True(t, ok)
+	assert.Equal(t, "server_name", entry.Name)
+
+	entry, ok = dactyloscopy.LookupGroup(0x001d)
+	assert.True(t, ok)
+	assert.Equal(t, "x25519", entry.Name)
+
+	entry, ok = dactyloscopy.LookupSignatureScheme(0x0201)
+	assert.True(t, ok)
+	assert.True(t, entry.Deprecated)
+
+	entry, ok = dactyloscopy.LookupECPointFormat(0)
+	assert.True(t, ok)
+	assert.Equal(t, "uncompressed", entry.Name)
+
+	entry, ok = dactyloscopy.LookupPSKKeyExchangeMode(1)
+	assert.True(t, ok)
+	assert.Equal(t, "psk_dhe_ke", entry.Name)
+
+	entry, ok = dactyloscopy.LookupCompressionMethod(1)
+	assert.True(t, ok)
+	assert.True(t, entry.Deprecated)
+
+	entry, ok = dactyloscopy.LookupTLSVersion(dactyloscopy.VersionTLS11)
+	assert.True(t, ok)
+	assert.True(t, entry.Deprecated)
+
+	entry, ok = dactyloscopy.LookupALPN("h2")
+	assert.True(t, ok)
+	assert.Equal(t, "HTTP/2 over TLS", entry.Name)
+}
+
+func TestIANANames(t *testing.T) {
+	assert.Equal(t, "TLS_CHACHA20_POLY1305_SHA256", dactyloscopy.GetIANACiphersuite(0x1303))
+	assert.Equal(t, "GREASE(0x3A3A)", dactyloscopy.GetIANACiphersuite(0x3a3a))
+	assert.Equal(t, "Ciphersuite(0x1234)", dactyloscopy.GetIANACiphersuite(0x1234))
+	assert.Equal(t, "X25519MLKEM768", dactyloscopy.GetIANAGroup(0x11ec))
+	assert.Equal(t, "ecdsa_secp256r1_sha256", dactyloscopy.GetIANASignatureScheme(0x0403))
+	assert.Equal(t, "TLS 1.3", dactyloscopy.GetIANAVersion(dactyloscopy.VersionTLS13))
+}
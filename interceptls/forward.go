@@ -0,0 +1,111 @@
+package interceptls
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+// FingerprintHeaders names the headers which carry fingerprints to upstream
+// services, so they don't need to link this package.  An empty name leaves
+// that header out
+type FingerprintHeaders struct {
+	JA3   string `json:"ja3,omitempty"`
+	JA3N  string `json:"ja3n,omitempty"`
+	JA4   string `json:"ja4,omitempty"`
+	JA4H  string `json:"ja4h,omitempty"`
+	HTTP2 string `json:"http2,omitempty"`
+	SNI   string `json:"sni,omitempty"`
+	// ALPN is the protocols the client offered, comma separated
+	ALPN string `json:"alpn,omitempty"`
+	// JSON is the whole fingerprint, JSON encoded
+	JSON string `json:"json,omitempty"`
+	// RawHello is the TLS record holding the ClientHello, base64 encoded
+	RawHello string `json:"raw_hello,omitempty"`
+}
+
+// DefaultFingerprintHeaders forwards the hashes, SNI and ALPN, leaving out the
+// larger JSON and raw ClientHello headers
+var DefaultFingerprintHeaders = FingerprintHeaders{
+	JA3:   "X-JA3-Fingerprint",
+	JA3N:  "X-JA3N-Fingerprint",
+	JA4:   "X-JA4-Fingerprint",
+	JA4H:  "X-JA4H-Fingerprint",
+	HTTP2: "X-HTTP2-Fingerprint",
+	SNI:   "X-TLS-SNI",
+	ALPN:  "X-TLS-ALPN",
+}
+
+func (h FingerprintHeaders) names() []string {
+	return []string{h.JA3, h.JA3N, h.JA4, h.JA4H, h.HTTP2, h.SNI, h.ALPN, h.JSON, h.RawHello}
+}
+
+// Strip removes any of the headers from header, so clients can't spoof them
+func (h FingerprintHeaders) Strip(header http.Header) {
+	for _, name := range h.names() {
+		if name != "" {
+			header.Del(name)
+		}
+	}
+}
+
+// Set replaces the headers in header with the fingerprints of r.  If r didn't
+// arrive through an intercepting listener, the headers are only stripped
+func (h FingerprintHeaders) Set(header http.Header, r *http.Request) {
+	h.Strip(header)
+	hello, ok := GetHelloFromRequest(r)
+	if !ok {
+		return
+	}
+	fp := GetFingerprintFromRequest(nil, r)
+
+	set := func(name, value string) {
+		if name != "" && value != "" {
+			header.Set(name, value)
+		}
+	}
+	set(h.JA3, fp.JA3)
+	set(h.JA3N, fp.JA3N)
+	set(h.JA4, fp.JA4)
+	set(h.JA4H, fp.JA4H)
+	set(h.HTTP2, fp.HTTP2)
+	set(h.SNI, fp.SNI)
+	set(h.ALPN, strings.Join(fp.ALPNProtocols, ","))
+	if h.JSON != "" {
+		if encoded, err := json.Marshal(fp); err == nil {
+			set(h.JSON, string(encoded))
+		}
+	}
+	set(h.RawHello, base64.StdEncoding.EncodeToString(hello.Raw))
+}
+
+// ForwardFingerprints is middleware which sets the headers on each request
+// before passing it to next, e.g. an httputil.ReverseProxy.  Client supplied
+// copies of the headers are always removed
+func ForwardFingerprints(h FingerprintHeaders, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.Set(r.Header, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Rewrite sets the headers on the outbound request, for use in
+// httputil.ReverseProxy.Rewrite
+func (h FingerprintHeaders) Rewrite(pr *httputil.ProxyRequest) {
+	h.Set(pr.Out.Header, pr.In)
+}
+
+// NewReverseProxy returns a reverse proxy to target which forwards the
+// headers, along with the usual X-Forwarded headers
+func NewReverseProxy(target *url.URL, h FingerprintHeaders) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.SetXForwarded()
+			h.Rewrite(pr)
+		},
+	}
+}
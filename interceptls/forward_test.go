@@ -0,0 +1,84 @@
+package interceptls_test
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+func TestNewReverseProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(r.Header)
+	}))
+	defer upstream.Close()
+	target, err := url.Parse(upstream.URL)
+	require.NoError(t, err)
+
+	headers := interceptls.DefaultFingerprintHeaders
+	headers.JSON = "X-TLS-Fingerprint"
+	headers.RawHello = "X-TLS-Client-Hello"
+	addr := startInterceptServer(t, interceptls.NewReverseProxy(target, headers), 0)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true, ServerName: "example.com"}, // #nosec G402 -- self-signed test cert
+			ForceAttemptHTTP2: true,
+		},
+		Timeout: 5 * time.Second,
+	}
+	req, err := http.NewRequest("GET", "https://"+addr, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-JA4-Fingerprint", "spoofed")
+	req.Header.Set("X-TLS-Client-Hello", "spoofed")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close() // nolint:errcheck
+
+	var got http.Header
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+
+	var fp interceptls.RequestFingerprint
+	require.NoError(t, json.Unmarshal([]byte(got.Get("X-TLS-Fingerprint")), &fp))
+	assert.Equal(t, []string{fp.JA4}, got.Values("X-JA4-Fingerprint"))
+	assert.NotEqual(t, "spoofed", fp.JA4)
+	assert.Equal(t, fp.JA3, got.Get("X-JA3-Fingerprint"))
+	assert.Equal(t, fp.JA3N, got.Get("X-JA3N-Fingerprint"))
+	assert.Equal(t, fp.JA4H, got.Get("X-JA4H-Fingerprint"))
+	assert.NotEmpty(t, got.Get("X-HTTP2-Fingerprint"))
+	assert.Equal(t, "example.com", got.Get("X-TLS-SNI"))
+	assert.Equal(t, "h2,http/1.1", got.Get("X-TLS-ALPN"))
+
+	raw, err := base64.StdEncoding.DecodeString(got.Get("X-TLS-Client-Hello"))
+	require.NoError(t, err)
+	parsed, err := dactyloscopy.ProcessClientHello(raw)
+	require.NoError(t, err)
+	assert.Equal(t, fp.JA4, parsed.JA4)
+	assert.NotEmpty(t, got.Get("X-Forwarded-For"))
+}
+
+func TestForwardFingerprintsStrips(t *testing.T) {
+	var got http.Header
+	handler := interceptls.ForwardFingerprints(interceptls.DefaultFingerprintHeaders, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+
+	// Not an interceptls request, so there is nothing to forward
+	r := httptest.NewRequest("GET", "https://example.com/", nil)
+	r.Header.Set("X-JA3-Fingerprint", "spoofed")
+	r.Header.Set("X-TLS-SNI", "spoofed")
+	r.Header.Set("X-Other", "kept")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(t, http.Header{"X-Other": {"kept"}}, got)
+}
@@ -0,0 +1,565 @@
+package interceptls
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+// ErrForwardProxyClosed is returned by Serve after Close
+var ErrForwardProxyClosed = errors.New("interceptls: forward proxy closed")
+
+// ErrDestinationDenied is recorded for tunnels to destinations which
+// AllowDestination refuses
+var ErrDestinationDenied = errors.New("interceptls: destination denied")
+
+// errAuthFailed is recorded for clients which fail Authenticate
+var errAuthFailed = errors.New("interceptls: proxy authentication failed")
+
+// Tunnel protocols, for TunnelRecord.Protocol
+const (
+	TunnelConnect = "connect"
+	TunnelSOCKS5  = "socks5"
+)
+
+// TunnelRecord describes a tunnel through a ForwardProxy, once it has ended
+type TunnelRecord struct {
+	Remote net.Addr
+	// Protocol is TunnelConnect or TunnelSOCKS5, empty if the client spoke
+	// neither
+	Protocol string
+	// User is the client's identity from Authenticate
+	User string
+	// Destination is the host:port the client asked for
+	Destination string
+	// TLS is set if the client started the tunnel with a ClientHello, in which
+	// case Fingerprint is set unless HelloErr is
+	TLS         bool
+	Fingerprint dactyloscopy.Fingerprint
+	HelloErr    error
+	// BytesUp were sent by the client through the tunnel, BytesDown by the
+	// destination
+	BytesUp   int64
+	BytesDown int64
+	Start     time.Time
+	Duration  time.Duration
+	// Err is why the tunnel couldn't be opened, or ended abnormally
+	Err error
+}
+
+// ForwardProxy is an HTTP CONNECT and SOCKS5 proxy which fingerprints the TLS
+// clients tunnelling through it, from the ClientHello at the start of each
+// tunnel, without decrypting anything.  Both protocols are served on the same
+// listener.  Tunnels which don't start with TLS are passed through, without a
+// fingerprint
+type ForwardProxy struct {
+	// Authenticate, if set, checks the client's username and password, from
+	// Basic Proxy-Authorization or SOCKS5 username/password authentication,
+	// and returns its identity.  Clients without credentials are refused
+	Authenticate func(username, password string) (string, error)
+	// AllowDestination decides whether a client may tunnel to an address.  It
+	// is asked about each address the destination resolves to, and the tunnel
+	// connects to one it allowed, so DNS can't be used to get around it.
+	// Defaults to PublicDestination, see DestinationRules for exceptions
+	AllowDestination func(user string, addr netip.AddrPort) bool
+	// Dial connects to the addresses AllowDestination allows, defaults to a
+	// net.Dialer
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+	// HandshakeTimeout bounds how long a client has to ask for a tunnel.
+	// Defaults to DefaultHelloTimeout, a negative value waits forever
+	HandshakeTimeout time.Duration
+	// AcceptError, if set, is called with each temporary error from the
+	// listener, such as running out of file descriptors, which is retried
+	// with a backoff
+	AcceptError func(err error)
+	// Log, if set, is called once each tunnel has ended
+	Log func(TunnelRecord)
+
+	listeners listenerSet
+}
+
+// ListenAndServe listens on the TCP address and serves proxy clients
+func (p *ForwardProxy) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return p.Serve(l)
+}
+
+// Serve serves proxy clients from the listener until it is closed
+func (p *ForwardProxy) Serve(l net.Listener) error {
+	return p.listeners.serve(l, ErrForwardProxyClosed, p.ServeConn, p.AcceptError)
+}
+
+// Close stops the proxy accepting new clients.  Open tunnels carry on until
+// they end
+func (p *ForwardProxy) Close() error {
+	return p.listeners.close()
+}
+
+// ServeConn serves a single proxy client, closing the connection once done
+func (p *ForwardProxy) ServeConn(conn net.Conn) {
+	record := TunnelRecord{Remote: conn.RemoteAddr(), Start: time.Now()}
+	defer func() {
+		_ = conn.Close()
+		record.Duration = time.Since(record.Start)
+		if p.Log != nil {
+			p.Log(record)
+		}
+	}()
+
+	if p.HandshakeTimeout >= 0 {
+		if record.Err = conn.SetDeadline(time.Now().Add(cmp.Or(p.HandshakeTimeout, DefaultHelloTimeout))); record.Err != nil {
+			return
+		}
+	}
+	br := bufio.NewReader(conn)
+	first, err := br.Peek(1)
+	if err != nil {
+		record.Err = err
+		return
+	}
+
+	var backend net.Conn
+	if first[0] == socks5Version {
+		record.Protocol = TunnelSOCKS5
+		backend, record.Err = p.socks5(conn, br, &record)
+	} else {
+		record.Protocol = TunnelConnect
+		backend, record.Err = p.connect(conn, br, &record)
+	}
+	if record.Err != nil {
+		return
+	}
+	defer backend.Close() // nolint:errcheck
+	if record.Err = conn.SetDeadline(time.Time{}); record.Err != nil {
+		return
+	}
+
+	// Anything the client sent along with its request is already buffered,
+	// otherwise read from the connection so the kernel can splice it
+	var client io.Reader = conn
+	if br.Buffered() > 0 {
+		client = br
+	}
+	record.BytesUp, record.BytesDown, record.Err = pipe(conn, backend, func() (int64, error) {
+		data, hello, helloErr, err := peekTunnel(client)
+		if len(data) > 0 && data[0] == tlsRecordHandshake {
+			record.TLS = true
+			if hello != nil {
+				record.Fingerprint, record.HelloErr = extractFP(hello)
+			} else {
+				record.HelloErr = cmp.Or(helloErr, err)
+			}
+		}
+		written, werr := backend.Write(data)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			// The client closed before sending anything more
+			return int64(written), werr
+		}
+		if err = cmp.Or(err, werr); err != nil {
+			return int64(written), err
+		}
+		n, err := io.Copy(backend, client)
+		return int64(written) + n, err
+	})
+}
+
+// dial connects a tunnel for the user to the first address the destination
+// resolves to which AllowDestination allows
+func (p *ForwardProxy) dial(user, destination string) (net.Conn, error) {
+	host, portStr, err := net.SplitHostPort(destination)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", portStr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	addrs := []netip.Addr{}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = append(addrs, addr)
+	} else if addrs, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host); err != nil {
+		return nil, err
+	}
+
+	allow := p.AllowDestination
+	if allow == nil {
+		allow = PublicDestination
+	}
+	dial := p.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	err = ErrDestinationDenied
+	for _, addr := range addrs {
+		addrPort := netip.AddrPortFrom(addr.Unmap(), uint16(port))
+		if !allow(user, addrPort) {
+			continue
+		}
+		var conn net.Conn
+		if conn, err = dial(ctx, "tcp", addrPort.String()); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// nonPublicPrefixes are special purpose ranges, from the IANA registries,
+// which netip.Addr has no method for.  The IPv6 ones are the deprecated
+// IPv4-compatible addresses, local-use NAT64 and Teredo, which embed IPv4
+// addresses we can't check
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("2001::/32"),
+}
+
+var (
+	// nat64Prefix is the well-known NAT64 prefix, with the IPv4 address in
+	// the last 32 bits
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	// sixToFourPrefix is 6to4, with the IPv4 address in the 32 bits after it
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
+// destinationIP returns the address a tunnel would really reach: the IPv4
+// address for IPv4-mapped, NAT64 and 6to4 addresses, which would otherwise
+// let clients reach IPv4 networks through IPv6 ones
+func destinationIP(addr netip.AddrPort) netip.Addr {
+	ip := addr.Addr().Unmap()
+	b := ip.As16()
+	switch {
+	case nat64Prefix.Contains(ip):
+		return netip.AddrFrom4([4]byte(b[12:16]))
+	case sixToFourPrefix.Contains(ip):
+		return netip.AddrFrom4([4]byte(b[2:6]))
+	}
+	return ip
+}
+
+// PublicDestination is the default ForwardProxy.AllowDestination.  It refuses
+// loopback, private, link-local (including cloud metadata services at
+// 169.254.169.254) and other special purpose addresses, including those
+// reached through NAT64 or 6to4, so the proxy can't be used to reach the
+// network it runs on
+func PublicDestination(_ string, addr netip.AddrPort) bool {
+	ip := destinationIP(addr)
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// DestinationRules returns a ForwardProxy.AllowDestination which refuses
+// addresses in deny, allows those in allow, and decides the rest with
+// PublicDestination.  NAT64 and 6to4 addresses are matched by the IPv4
+// address they embed
+func DestinationRules(allow, deny []netip.Prefix) func(string, netip.AddrPort) bool {
+	return func(user string, addr netip.AddrPort) bool {
+		ip := destinationIP(addr)
+		for _, prefix := range deny {
+			if prefix.Contains(ip) {
+				return false
+			}
+		}
+		for _, prefix := range allow {
+			if prefix.Contains(ip) {
+				return true
+			}
+		}
+		return PublicDestination(user, addr)
+	}
+}
+
+// connect handles an HTTP CONNECT request, returning the connection to the
+// destination once the client has been told it is open
+func (p *ForwardProxy) connect(conn net.Conn, br *bufio.Reader, record *TunnelRecord) (net.Conn, error) {
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return nil, err
+	}
+	if req.Method != http.MethodConnect {
+		writeStatus(conn, http.StatusMethodNotAllowed, nil)
+		return nil, fmt.Errorf("method %s isn't CONNECT", req.Method)
+	}
+	record.Destination = req.Host
+	if _, _, err := net.SplitHostPort(record.Destination); err != nil {
+		record.Destination = net.JoinHostPort(record.Destination, "443")
+	}
+
+	if p.Authenticate != nil {
+		username, password, ok := parseProxyAuthorization(req.Header.Get("Proxy-Authorization"))
+		if ok {
+			record.User, err = p.Authenticate(username, password)
+		}
+		if !ok || err != nil {
+			writeStatus(conn, http.StatusProxyAuthRequired, http.Header{"Proxy-Authenticate": {`Basic realm="proxy"`}})
+			return nil, errAuthFailed
+		}
+	}
+
+	backend, err := p.dial(record.User, record.Destination)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, ErrDestinationDenied) {
+			status = http.StatusForbidden
+		}
+		writeStatus(conn, status, nil)
+		return nil, err
+	}
+	if _, err := io.WriteString(conn, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		_ = backend.Close()
+		return nil, err
+	}
+	return backend, nil
+}
+
+// parseProxyAuthorization parses Basic credentials, as http.Request.BasicAuth
+// does for Authorization
+func parseProxyAuthorization(value string) (string, string, bool) {
+	req := http.Request{Header: http.Header{"Authorization": {value}}}
+	return req.BasicAuth()
+}
+
+func writeStatus(conn net.Conn, status int, header http.Header) {
+	resp := &http.Response{
+		StatusCode: status,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Close:      true,
+	}
+	_ = resp.Write(conn)
+}
+
+// SOCKS5 protocol values, from RFC 1928 and RFC 1929
+const (
+	socks5Version          = 0x05
+	socks5AuthVersion      = 0x01
+	socks5MethodNone       = 0x00
+	socks5MethodPassword   = 0x02
+	socks5MethodNoAccept   = 0xff
+	socks5CmdConnect       = 0x01
+	socks5AddrIPv4         = 0x01
+	socks5AddrDomain       = 0x03
+	socks5AddrIPv6         = 0x04
+	socks5Succeeded        = 0x00
+	socks5GeneralFailure   = 0x01
+	socks5NotAllowed       = 0x02
+	socks5CmdNotSupported  = 0x07
+	socks5AddrNotSupported = 0x08
+)
+
+// socks5 handles the SOCKS5 handshake, returning the connection to the
+// destination once the client has been told it is open
+func (p *ForwardProxy) socks5(conn net.Conn, br *bufio.Reader, record *TunnelRecord) (net.Conn, error) {
+	// Method negotiation
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, err
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(br, methods); err != nil {
+		return nil, err
+	}
+	method := byte(socks5MethodNone)
+	if p.Authenticate != nil {
+		method = socks5MethodPassword
+	}
+	if !bytes.Contains(methods, []byte{method}) {
+		_, _ = conn.Write([]byte{socks5Version, socks5MethodNoAccept})
+		return nil, fmt.Errorf("socks5: no acceptable auth method in %v", methods)
+	}
+	if _, err := conn.Write([]byte{socks5Version, method}); err != nil {
+		return nil, err
+	}
+
+	if method == socks5MethodPassword {
+		username, password, err := readSOCKS5Password(br)
+		if err != nil {
+			return nil, err
+		}
+		if record.User, err = p.Authenticate(username, password); err != nil {
+			_, _ = conn.Write([]byte{socks5AuthVersion, 0x01})
+			return nil, errAuthFailed
+		}
+		if _, err := conn.Write([]byte{socks5AuthVersion, 0x00}); err != nil {
+			return nil, err
+		}
+	}
+
+	// The request
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(br, request); err != nil {
+		return nil, err
+	}
+	reply := func(code byte) error {
+		_, err := conn.Write([]byte{socks5Version, code, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+		return err
+	}
+	host, err := readSOCKS5Addr(br, request[3])
+	if err != nil {
+		_ = reply(socks5AddrNotSupported)
+		return nil, err
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(br, port); err != nil {
+		return nil, err
+	}
+	record.Destination = net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+	if request[1] != socks5CmdConnect {
+		_ = reply(socks5CmdNotSupported)
+		return nil, fmt.Errorf("socks5: unsupported command %d", request[1])
+	}
+
+	backend, err := p.dial(record.User, record.Destination)
+	if err != nil {
+		code := byte(socks5GeneralFailure)
+		if errors.Is(err, ErrDestinationDenied) {
+			code = socks5NotAllowed
+		}
+		_ = reply(code)
+		return nil, err
+	}
+	if err := reply(socks5Succeeded); err != nil {
+		_ = backend.Close()
+		return nil, err
+	}
+	return backend, nil
+}
+
+func readSOCKS5Password(r io.Reader) (string, string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", "", err
+	}
+	if header[0] != socks5AuthVersion {
+		return "", "", fmt.Errorf("socks5: unsupported auth version %d", header[0])
+	}
+	username := make([]byte, header[1])
+	if _, err := io.ReadFull(r, username); err != nil {
+		return "", "", err
+	}
+	length := make([]byte, 1)
+	if _, err := io.ReadFull(r, length); err != nil {
+		return "", "", err
+	}
+	password := make([]byte, length[0])
+	if _, err := io.ReadFull(r, password); err != nil {
+		return "", "", err
+	}
+	return string(username), string(password), nil
+}
+
+func readSOCKS5Addr(r io.Reader, addrType byte) (string, error) {
+	var size int
+	switch addrType {
+	case socks5AddrIPv4:
+		size = net.IPv4len
+	case socks5AddrIPv6:
+		size = net.IPv6len
+	case socks5AddrDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(r, length); err != nil {
+			return "", err
+		}
+		size = int(length[0])
+	default:
+		return "", fmt.Errorf("socks5: unsupported address type %d", addrType)
+	}
+	addr := make([]byte, size)
+	if _, err := io.ReadFull(r, addr); err != nil {
+		return "", err
+	}
+	if addrType == socks5AddrDomain {
+		return string(addr), nil
+	}
+	return net.IP(addr).String(), nil
+}
+
+// tlsRecordHandshake is the TLS record type carrying a ClientHello
+const tlsRecordHandshake = 0x16
+
+// maxTunnelHello bounds the bytes peekTunnel reads looking for the end of a
+// ClientHello
+const maxTunnelHello = 1 << 16
+
+// peekTunnel reads the first data the client sends through a tunnel.  If that
+// is TLS, it carries on to the end of the ClientHello, which is also returned
+// reassembled into a single record for the parser if it was split over
+// several, or helloErr if that couldn't be done.  Otherwise it doesn't wait
+// for more than the client has sent, as some protocols wait for the server
+// first
+func peekTunnel(r io.Reader) (data, hello []byte, helloErr, err error) {
+	data = make([]byte, 4096)
+	n, err := r.Read(data)
+	data = data[:n]
+	if err != nil || n == 0 || data[0] != tlsRecordHandshake {
+		return data, nil, nil, err
+	}
+
+	// fill reads until data holds at least size bytes
+	fill := func(size int) error {
+		if len(data) >= size {
+			return nil
+		}
+		more := make([]byte, size-len(data))
+		n, err := io.ReadFull(r, more)
+		data = append(data, more[:n]...)
+		return err
+	}
+
+	// The handshake message is complete once its 4 byte header and the length
+	// it gives are in the payloads of the records read so far
+	var payload []byte
+	pos := 0
+	for len(payload) < 4 || len(payload) < 4+(int(payload[1])<<16|int(payload[2])<<8|int(payload[3])) {
+		if err := fill(pos + 5); err != nil {
+			return data, nil, nil, err
+		}
+		if data[pos] != tlsRecordHandshake {
+			return data, nil, fmt.Errorf("ClientHello interrupted by a record of type %d", data[pos]), nil
+		}
+		end := pos + 5 + (int(data[pos+3])<<8 | int(data[pos+4]))
+		if end > maxTunnelHello {
+			return data, nil, fmt.Errorf("ClientHello longer than %d bytes", maxTunnelHello), nil
+		}
+		if err := fill(end); err != nil {
+			return data, nil, nil, err
+		}
+		payload = append(payload, data[pos+5:end]...)
+		pos = end
+	}
+	if pos == 5+len(payload) {
+		return data, data[:pos], nil, nil
+	}
+	hello = append([]byte{data[0], data[1], data[2], byte(len(payload) >> 8), byte(len(payload))}, payload...)
+	return data, hello, nil, nil
+}
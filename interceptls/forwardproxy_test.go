@@ -0,0 +1,324 @@
+package interceptls_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/proxy"
+
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+// startForwardProxy starts a proxy for alice, which may tunnel to allow as
+// well as public addresses
+func startForwardProxy(t *testing.T, allow ...netip.Prefix) (string, chan interceptls.TunnelRecord) {
+	t.Helper()
+	records := make(chan interceptls.TunnelRecord, 4)
+	fp := &interceptls.ForwardProxy{
+		Authenticate: func(username, password string) (string, error) {
+			if username != "alice" || password != "secret" {
+				return "", errors.New("bad password")
+			}
+			return username, nil
+		},
+		AllowDestination: interceptls.DestinationRules(allow, nil),
+		Log:              func(r interceptls.TunnelRecord) { records <- r },
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go fp.Serve(ln) // nolint:errcheck
+	t.Cleanup(func() { _ = fp.Close() })
+	return ln.Addr().String(), records
+}
+
+func TestForwardProxyConnect(t *testing.T) {
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello")
+	}))
+	defer backend.Close()
+	addr, records := startForwardProxy(t, loopback)
+
+	get := func(user *url.Userinfo) (*http.Response, error) {
+		transport := &http.Transport{
+			Proxy:           http.ProxyURL(&url.URL{Scheme: "http", Host: addr, User: user}),
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true, ServerName: "www.example.com"}, // #nosec G402 -- self-signed test cert
+		}
+		defer transport.CloseIdleConnections()
+		client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+		resp, err := client.Get(backend.URL)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.ReadAll(resp.Body)
+		return resp, errors.Join(err, resp.Body.Close())
+	}
+
+	resp, err := get(url.UserPassword("alice", "secret"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	record := <-records
+	assert.NoError(t, record.Err)
+	assert.NoError(t, record.HelloErr)
+	assert.Equal(t, interceptls.TunnelConnect, record.Protocol)
+	assert.Equal(t, "alice", record.User)
+	assert.Equal(t, backend.Listener.Addr().String(), record.Destination)
+	assert.True(t, record.TLS)
+	assert.Equal(t, "www.example.com", record.Fingerprint.SNI)
+	assert.NotEmpty(t, record.Fingerprint.JA4)
+	assert.Positive(t, record.BytesUp)
+	assert.Positive(t, record.BytesDown)
+
+	// Bad credentials are refused before anything is dialled
+	_, err = get(url.UserPassword("alice", "wrong"))
+	assert.Error(t, err)
+	record = <-records
+	assert.Error(t, record.Err)
+	assert.Empty(t, record.User)
+	assert.Zero(t, record.BytesUp)
+}
+
+func TestForwardProxySOCKS5(t *testing.T) {
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello")
+	}))
+	defer backend.Close()
+	addr, records := startForwardProxy(t, loopback)
+
+	dialer, err := proxy.SOCKS5("tcp", addr, &proxy.Auth{User: "alice", Password: "secret"}, proxy.Direct)
+	require.NoError(t, err)
+	transport := &http.Transport{
+		DialContext:     dialer.(proxy.ContextDialer).DialContext,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true, ServerName: "www.example.com"}, // #nosec G402 -- self-signed test cert
+	}
+	client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+	resp, err := client.Get(backend.URL)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "hello", string(body))
+	transport.CloseIdleConnections()
+
+	record := <-records
+	assert.NoError(t, record.Err)
+	assert.Equal(t, interceptls.TunnelSOCKS5, record.Protocol)
+	assert.Equal(t, "alice", record.User)
+	assert.Equal(t, backend.Listener.Addr().String(), record.Destination)
+	assert.True(t, record.TLS)
+	assert.Equal(t, "www.example.com", record.Fingerprint.SNI)
+	assert.NotEmpty(t, record.Fingerprint.JA4)
+
+	// Bad credentials are refused
+	dialer, err = proxy.SOCKS5("tcp", addr, &proxy.Auth{User: "alice", Password: "wrong"}, proxy.Direct)
+	require.NoError(t, err)
+	_, err = dialer.Dial("tcp", backend.Listener.Addr().String())
+	assert.Error(t, err)
+	record = <-records
+	assert.Error(t, record.Err)
+}
+
+var loopback = netip.MustParsePrefix("127.0.0.0/8")
+
+func TestForwardProxyDestinationDenied(t *testing.T) {
+	backend := httptest.NewServer(http.NotFoundHandler())
+	defer backend.Close()
+	addr, records := startForwardProxy(t)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close() // nolint:errcheck
+	_, err = io.WriteString(conn, "CONNECT "+backend.Listener.Addr().String()+" HTTP/1.1\r\nProxy-Authorization: Basic YWxpY2U6c2VjcmV0\r\n\r\n")
+	require.NoError(t, err)
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	record := <-records
+	assert.ErrorIs(t, record.Err, interceptls.ErrDestinationDenied)
+	assert.Equal(t, "alice", record.User)
+
+	// Names are checked by the addresses they resolve to
+	dialer, err := proxy.SOCKS5("tcp", addr, &proxy.Auth{User: "alice", Password: "secret"}, proxy.Direct)
+	require.NoError(t, err)
+	_, err = dialer.Dial("tcp", "localhost:"+strconv.Itoa(backend.Listener.Addr().(*net.TCPAddr).Port))
+	assert.Error(t, err)
+	record = <-records
+	assert.ErrorIs(t, record.Err, interceptls.ErrDestinationDenied)
+}
+
+func TestDestinationRules(t *testing.T) {
+	allow := interceptls.DestinationRules(
+		[]netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")},
+		[]netip.Prefix{netip.MustParsePrefix("10.1.2.0/24"), netip.MustParsePrefix("203.0.113.0/24")},
+	)
+	for addr, want := range map[string]bool{
+		"93.184.215.14:443":          true,
+		"[2606:2800:21f:cb07::]:443": true,
+		"127.0.0.1:443":              false,
+		"[::1]:443":                  false,
+		"[::ffff:127.0.0.1]:443":     false,
+		"0.0.0.0:443":                false,
+		"169.254.169.254:80":         false,
+		"192.168.1.1:443":            false,
+		"100.64.0.1:443":             false,
+		"[fd00::1]:443":              false,
+		"[fe80::1]:443":              false,
+		"224.0.0.1:443":              false,
+		"10.1.3.4:443":               true,
+		"10.1.2.3:443":               false,
+		"10.2.0.1:443":               false,
+		"203.0.113.1:443":            false,
+		// NAT64 and 6to4 are judged by the IPv4 address they embed
+		"[64:ff9b::a9fe:a9fe]:80":   false,
+		"[64:ff9b::c0a8:101]:443":   false,
+		"[64:ff9b::a01:304]:443":    true,
+		"[64:ff9b::5db8:d70e]:443":  true,
+		"[2002:a9fe:a9fe::1]:80":    false,
+		"[2002:a01:203::1]:443":     false,
+		"[2002:5db8:d70e::1]:443":   true,
+		"[64:ff9b:1::a9fe:a9fe]:80": false,
+		"[2001:0:4136:e378::1]:443": false,
+		"[::a9fe:a9fe]:80":          false,
+	} {
+		assert.Equal(t, want, allow("alice", netip.MustParseAddrPort(addr)), addr)
+	}
+}
+
+// dialSOCKS5 opens a tunnel to dest through the proxy, doing the SOCKS5
+// handshake by hand to keep the *net.TCPConn for CloseWrite
+func dialSOCKS5(t *testing.T, addr string, dest *net.TCPAddr) *net.TCPConn {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	handshake := [][2][]byte{
+		{{0x05, 0x01, 0x02}, {0x05, 0x02}},
+		{append(append([]byte{0x01, 5}, "alice"...), append([]byte{6}, "secret"...)...), {0x01, 0x00}},
+		{append(append([]byte{0x05, 0x01, 0x00, 0x01}, dest.IP.To4()...), byte(dest.Port>>8), byte(dest.Port)), {0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, step := range handshake {
+		_, err = conn.Write(step[0])
+		require.NoError(t, err)
+		reply := make([]byte, len(step[1]))
+		_, err = io.ReadFull(conn, reply)
+		require.NoError(t, err)
+		require.Equal(t, step[1], reply)
+	}
+	return conn.(*net.TCPConn)
+}
+
+func TestForwardProxyPlaintext(t *testing.T) {
+	// A server which speaks first, to check the proxy doesn't wait for the
+	// client before passing data back
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close() // nolint:errcheck
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close() // nolint:errcheck
+		_, _ = io.WriteString(conn, "220 ready\r\n")
+		_, _ = io.Copy(conn, conn)
+	}()
+	addr, records := startForwardProxy(t, loopback)
+
+	conn := dialSOCKS5(t, addr, ln.Addr().(*net.TCPAddr))
+	greeting := make([]byte, len("220 ready\r\n"))
+	_, err = io.ReadFull(conn, greeting)
+	require.NoError(t, err)
+	assert.Equal(t, "220 ready\r\n", string(greeting))
+	_, err = io.WriteString(conn, "QUIT\r\n")
+	require.NoError(t, err)
+	require.NoError(t, conn.CloseWrite())
+	echo, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "QUIT\r\n", string(echo))
+	require.NoError(t, conn.Close())
+
+	record := <-records
+	assert.NoError(t, record.Err)
+	assert.False(t, record.TLS)
+	assert.Empty(t, record.Fingerprint.JA4)
+	assert.Equal(t, int64(len("QUIT\r\n")), record.BytesUp)
+	assert.Equal(t, int64(len("220 ready\r\nQUIT\r\n")), record.BytesDown)
+}
+
+func TestForwardProxyTunnelStart(t *testing.T) {
+	// A ClientHello record from crypto/tls
+	client, server := net.Pipe()
+	go func() { _ = tls.Client(client, &tls.Config{ServerName: "www.example.com"}).Handshake() }()
+	hello := make([]byte, 5)
+	_, err := io.ReadFull(server, hello)
+	require.NoError(t, err)
+	hello = append(hello, make([]byte, int(hello[3])<<8|int(hello[4]))...)
+	_, err = io.ReadFull(server, hello[5:])
+	require.NoError(t, err)
+	require.NoError(t, server.Close())
+
+	// record wraps part of the hello's handshake message in a record of its own
+	record := func(recordType byte, payload []byte) []byte {
+		return append([]byte{recordType, hello[1], hello[2], byte(len(payload) >> 8), byte(len(payload))}, payload...)
+	}
+	split := len(hello) / 2
+
+	for _, tc := range []struct {
+		name     string
+		sent     []byte
+		sni      string
+		helloErr bool
+	}{
+		{"one record", hello, "www.example.com", false},
+		{"split records", append(record(0x16, hello[5:split]), record(0x16, hello[split:])...), "www.example.com", false},
+		{"interrupted", append(record(0x16, hello[5:split]), record(0x17, hello[split:])...), "", true},
+		{"short header", hello[:2], "", true},
+		{"short record", hello[:split], "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer ln.Close() // nolint:errcheck
+			received := make(chan []byte, 1)
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close() // nolint:errcheck
+				data, _ := io.ReadAll(conn)
+				received <- data
+			}()
+			addr, records := startForwardProxy(t, loopback)
+
+			conn := dialSOCKS5(t, addr, ln.Addr().(*net.TCPAddr))
+			_, err = conn.Write(tc.sent)
+			require.NoError(t, err)
+			require.NoError(t, conn.CloseWrite())
+			_, _ = io.ReadAll(conn)
+			require.NoError(t, conn.Close())
+
+			// The destination gets exactly what was sent, however it was split
+			assert.Equal(t, tc.sent, <-received)
+			record := <-records
+			assert.NoError(t, record.Err)
+			assert.True(t, record.TLS)
+			assert.Equal(t, tc.sni, record.Fingerprint.SNI)
+			assert.Equal(t, tc.helloErr, record.HelloErr != nil, "HelloErr = %v", record.HelloErr)
+			assert.Equal(t, int64(len(tc.sent)), record.BytesUp)
+		})
+	}
+}
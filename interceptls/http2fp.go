@@ -0,0 +1,227 @@
+package interceptls
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2/hpack"
+)
+
+// HTTP/2 frame types and flags used by the fingerprint, from RFC 9113
+const (
+	http2FrameHeaders      = 0x1
+	http2FramePriority     = 0x2
+	http2FrameSettings     = 0x4
+	http2FrameWindowUpdate = 0x8
+	http2FrameContinuation = 0x9
+
+	http2FlagAck        = 0x1
+	http2FlagEndHeaders = 0x4
+	http2FlagPadded     = 0x8
+	http2FlagPriority   = 0x20
+
+	http2FrameHeaderLen = 9
+)
+
+// HTTP2Setting is a single SETTINGS parameter
+type HTTP2Setting struct {
+	ID    uint16 `json:"id"`
+	Value uint32 `json:"value"`
+}
+
+// HTTP2Priority is a PRIORITY frame.  Weight is the effective weight (1-256),
+// one more than the value on the wire
+type HTTP2Priority struct {
+	StreamID  uint32 `json:"stream_id"`
+	Exclusive bool   `json:"exclusive"`
+	DependsOn uint32 `json:"depends_on"`
+	Weight    uint16 `json:"weight"`
+}
+
+// HTTP2Fingerprint is the Akamai HTTP/2 fingerprint of a client, from the
+// frames sent at the start of the connection.  Clients' HTTP/2 stacks differ
+// in all of these, and they are rarely configurable
+type HTTP2Fingerprint struct {
+	// Settings from the client's first SETTINGS frame, in the order sent
+	Settings []HTTP2Setting `json:"settings"`
+	// WindowUpdate is the increment of the first connection level
+	// WINDOW_UPDATE, 0 if none was sent before the first request
+	WindowUpdate uint32 `json:"window_update"`
+	// Priorities are the PRIORITY frames sent before the first request
+	Priorities []HTTP2Priority `json:"priorities,omitempty"`
+	// PseudoHeaders are the pseudo-header names of the first request, in the
+	// order sent
+	PseudoHeaders []string `json:"pseudo_headers"`
+	// Truncated is set when the first request's headers weren't within the
+	// capture, in which case PseudoHeaders is empty
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// String returns the fingerprint in Akamai's format:
+//
+//	<settings>|<window update>|<priorities>|<pseudo-headers>
+//
+// e.g. 1:65536;2:0;4:6291456;6:262144|15663105|0|m,a,s,p
+func (f *HTTP2Fingerprint) String() string {
+	settings := make([]string, len(f.Settings))
+	for i, s := range f.Settings {
+		settings[i] = fmt.Sprintf("%d:%d", s.ID, s.Value)
+	}
+
+	windowUpdate := "00"
+	if f.WindowUpdate != 0 {
+		windowUpdate = fmt.Sprintf("%d", f.WindowUpdate)
+	}
+
+	priorities := "0"
+	if len(f.Priorities) > 0 {
+		p := make([]string, len(f.Priorities))
+		for i, prio := range f.Priorities {
+			exclusive := 0
+			if prio.Exclusive {
+				exclusive = 1
+			}
+			p[i] = fmt.Sprintf("%d:%d:%d:%d", prio.StreamID, exclusive, prio.DependsOn, prio.Weight)
+		}
+		priorities = strings.Join(p, ",")
+	}
+
+	pseudo := make([]string, 0, len(f.PseudoHeaders))
+	for _, name := range f.PseudoHeaders {
+		if name = strings.TrimPrefix(name, ":"); name != "" {
+			pseudo = append(pseudo, name[:1])
+		}
+	}
+
+	return strings.Join([]string{
+		strings.Join(settings, ";"),
+		windowUpdate,
+		priorities,
+		strings.Join(pseudo, ","),
+	}, "|")
+}
+
+// http2Parser reads the frames following the connection preface, up to and
+// including the headers of the first request
+type http2Parser struct {
+	fp          HTTP2Fingerprint
+	seenSetting bool
+	seenWindow  bool
+	// block collects a header block split over CONTINUATION frames
+	block    []byte
+	inHeader bool
+}
+
+// parse reads the complete frames in buf, returning the number of bytes
+// consumed and whether the first request's headers have been read
+func (p *http2Parser) parse(buf []byte) (int, bool) {
+	consumed := 0
+	for len(buf)-consumed >= http2FrameHeaderLen {
+		hdr := buf[consumed:]
+		length := int(hdr[0])<<16 | int(hdr[1])<<8 | int(hdr[2])
+		if len(hdr) < http2FrameHeaderLen+length {
+			break
+		}
+		frameType, flags := hdr[3], hdr[4]
+		streamID := binary.BigEndian.Uint32(hdr[5:9]) & 0x7fffffff
+		payload := hdr[http2FrameHeaderLen : http2FrameHeaderLen+length]
+		consumed += http2FrameHeaderLen + length
+
+		if p.frame(frameType, flags, streamID, payload) {
+			return consumed, true
+		}
+	}
+	return consumed, false
+}
+
+// frame handles a single frame, returning true once the first request's
+// headers are complete
+func (p *http2Parser) frame(frameType, flags uint8, streamID uint32, payload []byte) bool {
+	switch frameType {
+	case http2FrameSettings:
+		if flags&http2FlagAck != 0 || p.seenSetting {
+			return false
+		}
+		p.seenSetting = true
+		for ; len(payload) >= 6; payload = payload[6:] {
+			p.fp.Settings = append(p.fp.Settings, HTTP2Setting{
+				ID:    binary.BigEndian.Uint16(payload),
+				Value: binary.BigEndian.Uint32(payload[2:]),
+			})
+		}
+
+	case http2FrameWindowUpdate:
+		if streamID != 0 || p.seenWindow || len(payload) < 4 {
+			return false
+		}
+		p.seenWindow = true
+		p.fp.WindowUpdate = binary.BigEndian.Uint32(payload) & 0x7fffffff
+
+	case http2FramePriority:
+		if len(payload) < 5 {
+			return false
+		}
+		dep := binary.BigEndian.Uint32(payload)
+		p.fp.Priorities = append(p.fp.Priorities, HTTP2Priority{
+			StreamID:  streamID,
+			Exclusive: dep&0x80000000 != 0,
+			DependsOn: dep & 0x7fffffff,
+			Weight:    uint16(payload[4]) + 1,
+		})
+
+	case http2FrameHeaders:
+		if flags&http2FlagPadded != 0 {
+			if len(payload) < 1 || int(payload[0]) > len(payload)-1 {
+				return true
+			}
+			payload = payload[1 : len(payload)-int(payload[0])]
+		}
+		if flags&http2FlagPriority != 0 {
+			if len(payload) < 5 {
+				return true
+			}
+			payload = payload[5:]
+		}
+		p.block = append(p.block, payload...)
+		p.inHeader = true
+		return p.endHeaders(flags)
+
+	case http2FrameContinuation:
+		if !p.inHeader {
+			return false
+		}
+		p.block = append(p.block, payload...)
+		return p.endHeaders(flags)
+	}
+	return false
+}
+
+// endHeaders decodes the header block once it is complete
+func (p *http2Parser) endHeaders(flags uint8) bool {
+	if flags&http2FlagEndHeaders == 0 {
+		return false
+	}
+	fields, err := hpack.NewDecoder(4096, nil).DecodeFull(p.block)
+	if err == nil {
+		for _, f := range fields {
+			if f.IsPseudo() {
+				p.fp.PseudoHeaders = append(p.fp.PseudoHeaders, f.Name)
+			}
+		}
+	}
+	p.block = nil
+	return true
+}
+
+// GetHTTP2FingerprintFromRequest returns the HTTP/2 fingerprint of the
+// connection the request arrived on.  It returns false for HTTP/1.x, and for
+// requests which didn't arrive through an intercepting listener
+func GetHTTP2FingerprintFromRequest(r *http.Request) (*HTTP2Fingerprint, bool) {
+	hc, ok := r.Context().Value(helloConnKey).(*HelloConn)
+	if !ok || hc.capture == nil {
+		return nil, false
+	}
+	return hc.capture.http2Fingerprint()
+}
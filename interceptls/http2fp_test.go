@@ -0,0 +1,130 @@
+package interceptls_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+type http2Response struct {
+	Found       bool                          `json:"found"`
+	Fingerprint *interceptls.HTTP2Fingerprint `json:"fingerprint"`
+	HTTP2       string                        `json:"http2"`
+}
+
+func http2Handler(w http.ResponseWriter, r *http.Request) {
+	h2, found := interceptls.GetHTTP2FingerprintFromRequest(r)
+	fp := interceptls.GetFingerprintFromRequest(w, r)
+	_ = json.NewEncoder(w).Encode(http2Response{Found: found, Fingerprint: h2, HTTP2: fp.HTTP2})
+}
+
+// http2Request makes a request with Firefox-like connection setup, returning
+// the decoded response
+func http2Request(t *testing.T, addr string) http2Response {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"h2"}}) // #nosec G402 -- self-signed test cert
+	require.NoError(t, err)
+	defer conn.Close() // nolint:errcheck
+
+	_, err = conn.Write([]byte(http2.ClientPreface))
+	require.NoError(t, err)
+	framer := http2.NewFramer(conn, conn)
+	require.NoError(t, framer.WriteSettings(
+		http2.Setting{ID: http2.SettingHeaderTableSize, Val: 65536},
+		http2.Setting{ID: http2.SettingInitialWindowSize, Val: 131072},
+		http2.Setting{ID: http2.SettingMaxFrameSize, Val: 16384},
+	))
+	require.NoError(t, framer.WriteWindowUpdate(0, 12517377))
+	require.NoError(t, framer.WritePriority(3, http2.PriorityParam{Weight: 200}))
+	require.NoError(t, framer.WritePriority(5, http2.PriorityParam{Weight: 100, Exclusive: true, StreamDep: 3}))
+
+	var block bytes.Buffer
+	enc := hpack.NewEncoder(&block)
+	for _, f := range [][2]string{{":method", "GET"}, {":path", "/"}, {":authority", "example.com"}, {":scheme", "https"}, {"user-agent", "test"}} {
+		require.NoError(t, enc.WriteField(hpack.HeaderField{Name: f[0], Value: f[1]}))
+	}
+	// Split the header block, to check CONTINUATION frames are followed
+	require.NoError(t, framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      1,
+		BlockFragment: block.Bytes()[:4],
+		EndStream:     true,
+	}))
+	require.NoError(t, framer.WriteContinuation(1, true, block.Bytes()[4:]))
+
+	var body []byte
+	for {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err)
+		switch f := frame.(type) {
+		case *http2.SettingsFrame:
+			if !f.IsAck() {
+				require.NoError(t, framer.WriteSettingsAck())
+			}
+		case *http2.DataFrame:
+			body = append(body, f.Data()...)
+			if f.StreamEnded() {
+				var decoded http2Response
+				require.NoError(t, json.Unmarshal(body, &decoded))
+				return decoded
+			}
+		case *http2.GoAwayFrame:
+			t.Fatalf("server sent GOAWAY: %v", f.ErrCode)
+		}
+	}
+}
+
+func TestHTTP2Fingerprint(t *testing.T) {
+	addr := startInterceptServer(t, http.HandlerFunc(http2Handler), 0)
+
+	resp := http2Request(t, addr)
+	require.True(t, resp.Found)
+	assert.Equal(t, &interceptls.HTTP2Fingerprint{
+		Settings: []interceptls.HTTP2Setting{
+			{ID: 1, Value: 65536},
+			{ID: 4, Value: 131072},
+			{ID: 5, Value: 16384},
+		},
+		WindowUpdate: 12517377,
+		Priorities: []interceptls.HTTP2Priority{
+			{StreamID: 3, Weight: 201},
+			{StreamID: 5, Exclusive: true, DependsOn: 3, Weight: 101},
+		},
+		PseudoHeaders: []string{":method", ":path", ":authority", ":scheme"},
+	}, resp.Fingerprint)
+	assert.Equal(t, "1:65536;4:131072;5:16384|12517377|3:0:0:201,5:1:3:101|m,p,a,s", resp.HTTP2)
+}
+
+func TestHTTP2FingerprintHTTP1(t *testing.T) {
+	addr := startInterceptServer(t, http.HandlerFunc(http2Handler), 0)
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true}) // #nosec G402 -- self-signed test cert
+	require.NoError(t, err)
+	defer conn.Close() // nolint:errcheck
+	req, err := http.NewRequest("GET", "https://example.com/", nil)
+	require.NoError(t, err)
+	require.NoError(t, req.Write(conn))
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	require.NoError(t, err)
+	defer resp.Body.Close() // nolint:errcheck
+
+	var decoded http2Response
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	assert.False(t, decoded.Found)
+	assert.Empty(t, decoded.HTTP2)
+}
+
+func TestHTTP2FingerprintString(t *testing.T) {
+	// Nothing but the pseudo-headers
+	fp := &interceptls.HTTP2Fingerprint{PseudoHeaders: []string{":method", ":authority", ":scheme", ":path"}}
+	assert.Equal(t, "|00|0|m,a,s,p", fp.String())
+}
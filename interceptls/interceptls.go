@@ -2,12 +2,16 @@ package interceptls
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/LeeBrotherston/dactyloscopy"
 )
@@ -17,31 +21,180 @@ type contextKey string
 
 const ClientHelloKey contextKey = "clientHelloInfo"
 
+// helloConnKey holds the *HelloConn, for things which are only known once the
+// connection has been read from
+const helloConnKey contextKey = "helloConn"
+
 // Custom Conn
 type HelloConn struct {
 	net.Conn
 	DactFP *dactyloscopy.Fingerprint
+	// RawHello is the TLS record holding the ClientHello, as received
+	RawHello []byte
+	// HelloErr is why the ClientHello couldn't be parsed, in which case
+	// DactFP is empty
+	HelloErr error
+
+	capture *plaintextCapture
 }
 
 func (c *HelloConn) HelloFP() *dactyloscopy.Fingerprint {
 	return c.DactFP
 }
 
+// Read reads decrypted data from the connection, recording the start of it
+func (c *HelloConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 && c.capture != nil {
+		c.capture.write(b[:n])
+	}
+	return n, err
+}
+
+// HandshakeContext runs the TLS handshake, if it hasn't already happened.
+// Together with ConnectionState, this lets http.Server treat a HelloConn as
+// TLS, so it sets r.TLS and serves HTTP/2 when h2 is negotiated
+func (c *HelloConn) HandshakeContext(ctx context.Context) error {
+	if tlsConn, ok := c.Conn.(*tls.Conn); ok {
+		return tlsConn.HandshakeContext(ctx)
+	}
+	return nil
+}
+
+// ConnectionState returns the TLS state of the connection
+func (c *HelloConn) ConnectionState() tls.ConnectionState {
+	if tlsConn, ok := c.Conn.(*tls.Conn); ok {
+		return tlsConn.ConnectionState()
+	}
+	return tls.ConnectionState{}
+}
+
+// NetConn returns the *tls.Conn wrapped by the HelloConn
+func (c *HelloConn) NetConn() net.Conn {
+	return c.Conn
+}
+
 // Custom Listener
 type inspectingListener struct {
 	net.Listener
 	tlsConfig *tls.Config
+
+	// CaptureSize is the number of bytes of plaintext recorded from the start
+	// of each connection, for GetRawHeadersFromRequest.  Defaults to
+	// DefaultCaptureSize, a negative value disables capturing
+	CaptureSize int
+
+	// HelloTimeout bounds how long a client has to send its ClientHello.
+	// Defaults to DefaultHelloTimeout, a negative value waits forever
+	HelloTimeout time.Duration
+
+	// MaxPending caps the connections waiting for a ClientHello, after which
+	// no more are accepted until one completes.  Defaults to
+	// DefaultMaxPending, a negative value is unlimited
+	MaxPending int
+
+	// HelloError, if set, is called with each connection which is dropped
+	// because its ClientHello couldn't be read.  Such connections are never
+	// returned from Accept
+	HelloError func(addr net.Addr, err error)
+
+	// AcceptError, if set, is called with each temporary error from the
+	// underlying listener, such as running out of file descriptors.  These
+	// are retried with a backoff rather than returned from Accept
+	AcceptError func(err error)
+
+	// Policy, if set, decides what to do with each connection once its
+	// ClientHello has been read.  Connections it doesn't accept are never
+	// returned from Accept
+	Policy Policy
+
+	// GetConfigForClient, if set, picks the tls.Config for each handshake
+	// with the client's fingerprint at hand, e.g. to give legacy clients an
+	// RSA certificate.  As with tls.Config.GetConfigForClient, returning a nil
+	// config uses the listener's (or the Policy's).  The returned config is
+	// used as is, so should set NextProtos to offer h2
+	GetConfigForClient func(fp *dactyloscopy.Fingerprint, info *tls.ClientHelloInfo) (*tls.Config, error)
+
+	start sync.Once
+	conns chan net.Conn
+	// done is closed once the underlying listener fails, after setting err
+	done chan struct{}
+	err  error
 }
 
+const (
+	// DefaultHelloTimeout is how long clients have to send a ClientHello
+	DefaultHelloTimeout = 10 * time.Second
+	// DefaultMaxPending is the number of connections which may be waiting to
+	// send a ClientHello at once
+	DefaultMaxPending = 1024
+)
+
 func ConnContextHandler(ctx context.Context, c net.Conn) context.Context {
 	if hc, ok := c.(*HelloConn); ok {
+		ctx = context.WithValue(ctx, helloConnKey, hc)
 		return context.WithValue(ctx, ClientHelloKey, hc.DactFP)
 	}
 	return ctx
 }
 
-func GetFingerprintFromRequest(w http.ResponseWriter, r *http.Request) dactyloscopy.Fingerprint {
-	return GetFingerprintFromContext(r.Context())
+// RequestFingerprint is the TLS fingerprint of a connection, with the HTTP
+// fingerprints of a request made over it
+type RequestFingerprint struct {
+	dactyloscopy.Fingerprint
+	// JA4H is the HTTP client fingerprint of the request
+	JA4H string `json:"ja4h,omitempty"`
+	// HTTP2 is the Akamai HTTP/2 fingerprint of the connection, empty for
+	// HTTP/1 clients
+	HTTP2 string `json:"http2,omitempty"`
+}
+
+// GetFingerprintFromRequest returns the TLS fingerprint of the connection the
+// request arrived on, with the JA4H fingerprint of the request itself and the
+// HTTP/2 fingerprint of the connection
+func GetFingerprintFromRequest(w http.ResponseWriter, r *http.Request) RequestFingerprint {
+	fp := RequestFingerprint{Fingerprint: GetFingerprintFromContext(r.Context())}
+	if h2, ok := GetHTTP2FingerprintFromRequest(r); ok {
+		fp.HTTP2 = h2.String()
+	}
+	if raw, ok := GetRawHeadersFromRequest(r); ok && !raw.Truncated {
+		fp.JA4H = ja4h(r, raw.Names())
+	} else {
+		fp.JA4H = JA4H(r)
+	}
+	return fp
+}
+
+// HelloInfo is the ClientHello of a connection, and the result of parsing it
+type HelloInfo struct {
+	// Fingerprint is empty if the ClientHello couldn't be parsed
+	Fingerprint dactyloscopy.Fingerprint
+	// Raw is the TLS record holding the ClientHello, as received
+	Raw []byte
+	// Err is why the ClientHello couldn't be parsed, if it couldn't
+	Err error
+}
+
+// GetHelloFromContext returns the ClientHello of the connection.  Unlike
+// GetFingerprintFromContext, it tells connections which didn't come from an
+// intercepting listener (false), from ClientHellos which couldn't be parsed
+// (Err set)
+func GetHelloFromContext(ctx context.Context) (HelloInfo, bool) {
+	hc, ok := ctx.Value(helloConnKey).(*HelloConn)
+	if !ok {
+		return HelloInfo{}, false
+	}
+	info := HelloInfo{Raw: hc.RawHello, Err: hc.HelloErr}
+	if hc.DactFP != nil {
+		info.Fingerprint = *hc.DactFP
+	}
+	return info, true
+}
+
+// GetHelloFromRequest returns the ClientHello of the connection the request
+// arrived on, see GetHelloFromContext
+func GetHelloFromRequest(r *http.Request) (HelloInfo, bool) {
+	return GetHelloFromContext(r.Context())
 }
 
 func GetFingerprintFromContext(ctx context.Context) dactyloscopy.Fingerprint {
@@ -51,27 +204,182 @@ func GetFingerprintFromContext(ctx context.Context) dactyloscopy.Fingerprint {
 	return dactyloscopy.Fingerprint{}
 }
 
+// Accept returns the next connection whose ClientHello has been read.  The
+// ClientHellos are read concurrently, so a slow client doesn't hold up others,
+// and connections which fail are closed and passed to HelloError rather than
+// returned as an error
 func (l *inspectingListener) Accept() (net.Conn, error) {
-	conn, err := l.Listener.Accept()
+	l.start.Do(func() { go l.acceptLoop() })
+
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, l.err
+	}
+}
+
+// acceptLoop accepts connections from the underlying listener, and starts
+// reading their ClientHellos
+func (l *inspectingListener) acceptLoop() {
+	var pending chan struct{}
+	if l.MaxPending >= 0 {
+		pending = make(chan struct{}, cmp.Or(l.MaxPending, DefaultMaxPending))
+	}
+
+	// Temporary errors are retried with a backoff, as http.Server does, rather
+	// than returned from Accept, which nothing may be calling
+	var delay time.Duration
+	for {
+		if pending != nil {
+			pending <- struct{}{}
+		}
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			if pending != nil {
+				<-pending
+			}
+			if temporary(err) {
+				if l.AcceptError != nil {
+					l.AcceptError(err)
+				}
+				delay = nextAcceptDelay(delay)
+				time.Sleep(delay)
+				continue
+			}
+			l.err = err
+			close(l.done)
+			return
+		}
+		delay = 0
+
+		go l.handle(conn, pending)
+	}
+}
+
+// temporary reports whether an error from Accept is worth retrying, as
+// http.Server does.  That includes timeouts and running out of file
+// descriptors, but not the listener being closed
+func temporary(err error) bool {
+	if errors.Is(err, net.ErrClosed) {
+		return false
+	}
+	var tempErr interface{ Temporary() bool }
+	return errors.As(err, &tempErr) && tempErr.Temporary()
+}
+
+// nextAcceptDelay returns how long to wait before retrying Accept after a
+// temporary error, doubling from 5ms up to 1s as http.Server does
+func nextAcceptDelay(delay time.Duration) time.Duration {
+	return min(max(2*delay, 5*time.Millisecond), time.Second)
+}
+
+// handle reads the connection's ClientHello, and carries out the policy for
+// it.  pending is released once the ClientHello has been read, so tarpitted
+// connections don't count against MaxPending
+func (l *inspectingListener) handle(conn net.Conn, pending chan struct{}) {
+	hc, decision, err := l.inspect(conn)
+	if pending != nil {
+		<-pending
+	}
 	if err != nil {
-		return nil, err
+		_ = conn.Close()
+		if l.HelloError != nil {
+			l.HelloError(conn.RemoteAddr(), err)
+		}
+		return
 	}
 
+	switch decision.Action {
+	case PolicyAccept, PolicyTLSConfig:
+		select {
+		case l.conns <- hc:
+		case <-l.done:
+			_ = conn.Close()
+		}
+	case PolicyReset:
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			_ = tcpConn.SetLinger(0)
+		}
+		_ = conn.Close()
+	case PolicyDelay:
+		timer := time.NewTimer(decision.Delay)
+		select {
+		case <-timer.C:
+		case <-l.done:
+			timer.Stop()
+		}
+		_ = conn.Close()
+	default:
+		_ = conn.Close()
+	}
+}
+
+// inspect reads and fingerprints the ClientHello, and applies the policy,
+// returning the connection ready for the TLS handshake
+func (l *inspectingListener) inspect(conn net.Conn) (*HelloConn, PolicyDecision, error) {
+	if l.HelloTimeout >= 0 {
+		if err := conn.SetReadDeadline(time.Now().Add(cmp.Or(l.HelloTimeout, DefaultHelloTimeout))); err != nil {
+			return nil, PolicyDecision{}, err
+		}
+	}
 	peeked, err := peekClientHello(conn)
 	if err != nil {
-		return nil, fmt.Errorf("peekClientHello: %w", err)
+		return nil, PolicyDecision{}, fmt.Errorf("peekClientHello: %w", err)
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, PolicyDecision{}, err
 	}
 
-	parsedHello := parseClientHello(peeked)
+	parsedHello, parseErr := parseClientHello(peeked)
+
+	var decision PolicyDecision
+	if l.Policy != nil {
+		decision = l.Policy(HelloInfo{Fingerprint: *parsedHello, Raw: peeked, Err: parseErr}, conn.RemoteAddr())
+	}
+	tlsConfig := l.tlsConfig
+	if decision.Action == PolicyTLSConfig && decision.TLSConfig != nil {
+		tlsConfig = decision.TLSConfig
+	}
+	if l.GetConfigForClient != nil {
+		tlsConfig = l.configForClient(tlsConfig, parsedHello)
+	}
 
 	reader := io.MultiReader(bytes.NewReader(peeked), conn)
 	wrapped := &readFirstConn{Conn: conn, Reader: reader}
-	tlsConn := tls.Server(wrapped, l.tlsConfig)
+	tlsConn := tls.Server(wrapped, tlsConfig)
+
+	hc := &HelloConn{
+		Conn:     tlsConn,
+		DactFP:   parsedHello,
+		RawHello: peeked,
+		HelloErr: parseErr,
+	}
+	if l.CaptureSize >= 0 {
+		hc.capture = newPlaintextCapture(l.CaptureSize)
+	}
+	return hc, decision, nil
+}
 
-	return &HelloConn{
-		Conn:   tlsConn,
-		DactFP: parsedHello,
-	}, nil
+// configForClient returns a copy of base which calls GetConfigForClient with
+// the fingerprint, falling back to base's own GetConfigForClient
+func (l *inspectingListener) configForClient(base *tls.Config, fp *dactyloscopy.Fingerprint) *tls.Config {
+	config := base.Clone()
+	if config == nil {
+		config = &tls.Config{}
+	}
+	fallback := config.GetConfigForClient
+	config.GetConfigForClient = func(info *tls.ClientHelloInfo) (*tls.Config, error) {
+		chosen, err := l.GetConfigForClient(fp, info)
+		if err != nil || chosen != nil {
+			return chosen, err
+		}
+		if fallback != nil {
+			return fallback(info)
+		}
+		return nil, nil
+	}
+	return config
 }
 
 type readFirstConn struct {
@@ -98,20 +406,30 @@ func peekClientHello(conn net.Conn) ([]byte, error) {
 	return append(hdr, body...), nil
 }
 
-func parseClientHello(data []byte) *dactyloscopy.Fingerprint {
-	stuff := extractFP(data)
-	return &stuff
+func parseClientHello(data []byte) (*dactyloscopy.Fingerprint, error) {
+	stuff, err := extractFP(data)
+	return &stuff, err
 }
 
-func extractFP(data []byte) dactyloscopy.Fingerprint {
+func extractFP(data []byte) (dactyloscopy.Fingerprint, error) {
 	var tlsfp dactyloscopy.Fingerprint
 	err := tlsfp.ProcessClientHello(data)
 	if err != nil {
-		return dactyloscopy.Fingerprint{}
+		return dactyloscopy.Fingerprint{}, err
 	}
-	return tlsfp
+	return tlsfp, nil
 }
 
+// NewInterceptListener returns a listener which fingerprints each client, then
+// serves TLS using tlsConfig.  The HelloConns it returns are served by
+// http.Server as TLS, with r.TLS set.  Unlike http.Server.ServeTLS, tlsConfig
+// is used as is, so set its NextProtos to []string{"h2", "http/1.1"} to serve
+// HTTP/2
 func NewInterceptListener(listener net.Listener, tlsConfig *tls.Config) *inspectingListener {
-	return &inspectingListener{Listener: listener, tlsConfig: tlsConfig}
+	return &inspectingListener{
+		Listener:  listener,
+		tlsConfig: tlsConfig,
+		conns:     make(chan net.Conn),
+		done:      make(chan struct{}),
+	}
 }
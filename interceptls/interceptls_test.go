@@ -1,21 +1,31 @@
 package interceptls_test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"math/big"
 	"net"
 	"net/http"
+	"os"
+	"slices"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/LeeBrotherston/dactyloscopy"
 	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,6 +58,7 @@ func TestInterceptListener_CapturesJA3(t *testing.T) {
 			err = fp.MakeHashes()
 			require.NoError(t, err)
 			require.NotEmpty(t, fp.JA3)
+			require.NotEmpty(t, fp.JA4H)
 			_, err = w.Write([]byte(fp.JA3))
 			if err != nil {
 				t.Errorf("error writing bytes, err=[%s]", err)
@@ -84,6 +95,284 @@ func TestInterceptListener_CapturesJA3(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+type serverResponse struct {
+	ProtoMajor int    `json:"proto_major"`
+	TLS        bool   `json:"tls"`
+	ALPN       string `json:"alpn"`
+	SNI        string `json:"sni"`
+	JA4        string `json:"ja4"`
+	HTTP2      string `json:"http2"`
+}
+
+func serverHandler(w http.ResponseWriter, r *http.Request) {
+	fp := interceptls.GetFingerprintFromRequest(w, r)
+	resp := serverResponse{ProtoMajor: r.ProtoMajor, TLS: r.TLS != nil, JA4: fp.JA4, HTTP2: fp.HTTP2}
+	if r.TLS != nil {
+		resp.ALPN = r.TLS.NegotiatedProtocol
+		resp.SNI = r.TLS.ServerName
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func getServerResponse(t *testing.T, transport *http.Transport, url string) serverResponse {
+	t.Helper()
+	client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+	defer transport.CloseIdleConnections()
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close() // nolint:errcheck
+
+	var decoded serverResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return decoded
+}
+
+func TestInterceptListenerHTTP2(t *testing.T) {
+	addr := startInterceptServer(t, http.HandlerFunc(serverHandler), 0)
+
+	resp := getServerResponse(t, &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true, ServerName: "example.com"}, // #nosec G402 -- self-signed test cert
+		ForceAttemptHTTP2: true,
+	}, "https://"+addr)
+
+	assert.Equal(t, serverResponse{
+		ProtoMajor: 2,
+		TLS:        true,
+		ALPN:       "h2",
+		SNI:        "example.com",
+		JA4:        resp.JA4,
+		HTTP2:      resp.HTTP2,
+	}, resp)
+	assert.NotEmpty(t, resp.JA4)
+	assert.NotEmpty(t, resp.HTTP2)
+}
+
+func TestInterceptListenerHTTP1(t *testing.T) {
+	addr := startInterceptServer(t, http.HandlerFunc(serverHandler), 0)
+
+	resp := getServerResponse(t, &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"http/1.1"}}, // #nosec G402 -- self-signed test cert
+	}, "https://"+addr)
+
+	assert.Equal(t, 1, resp.ProtoMajor)
+	assert.True(t, resp.TLS)
+	assert.Equal(t, "http/1.1", resp.ALPN)
+	assert.NotEmpty(t, resp.JA4)
+	assert.Empty(t, resp.HTTP2)
+}
+
+func TestInterceptListenerSlowClient(t *testing.T) {
+	certPEM, keyPEM, err := generateSelfSignedCert(t)
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	wrapped := interceptls.NewInterceptListener(ln, &tls.Config{Certificates: []tls.Certificate{cert}})
+	wrapped.HelloTimeout = 200 * time.Millisecond
+	helloErrors := make(chan error, 1)
+	wrapped.HelloError = func(addr net.Addr, err error) { helloErrors <- err }
+
+	// A client which connects and sends nothing
+	silent, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer silent.Close() // nolint:errcheck
+
+	// doesn't hold up the next client
+	go func() {
+		conn, err := tls.Dial("tcp", ln.Addr().String(), &tls.Config{InsecureSkipVerify: true}) // #nosec G402 -- self-signed test cert
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+	conn, err := wrapped.Accept()
+	require.NoError(t, err)
+	hc, ok := conn.(*interceptls.HelloConn)
+	require.True(t, ok)
+	assert.NotEmpty(t, hc.DactFP.Ciphersuite)
+	require.NoError(t, hc.HandshakeContext(context.Background()))
+	require.NoError(t, conn.Close())
+
+	// and is dropped once it times out, without Accept returning an error
+	select {
+	case err := <-helloErrors:
+		assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("silent client wasn't dropped")
+	}
+	require.NoError(t, silent.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = silent.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+
+	// Closing the listener ends Accept
+	require.NoError(t, wrapped.Close())
+	_, err = wrapped.Accept()
+	assert.ErrorIs(t, err, net.ErrClosed)
+}
+
+// flakyListener fails its first few Accepts with err
+type flakyListener struct {
+	net.Listener
+	err      error
+	failures int
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.failures > 0 {
+		l.failures--
+		return nil, l.err
+	}
+	return l.Listener.Accept()
+}
+
+func TestInterceptListenerAcceptError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantRetry bool
+	}{
+		{"timeout", os.ErrDeadlineExceeded, true},
+		{"out of file descriptors", &net.OpError{Op: "accept", Net: "tcp", Err: os.NewSyscallError("accept4", syscall.EMFILE)}, true},
+		{"wrapped", fmt.Errorf("accepting: %w", syscall.ENFILE), true},
+		{"permanent", errors.New("listener broken"), false},
+		{"closed", net.ErrClosed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			wrapped := interceptls.NewInterceptListener(&flakyListener{Listener: ln, err: tt.err, failures: 2}, &tls.Config{Certificates: []tls.Certificate{loadTestCert(t)}})
+			defer wrapped.Close() // nolint:errcheck
+			acceptErrors := make(chan error, 2)
+			wrapped.AcceptError = func(err error) { acceptErrors <- err }
+			wrapped.HelloError = func(addr net.Addr, err error) { t.Errorf("HelloError(%v, %v)", addr, err) }
+
+			if !tt.wantRetry {
+				_, err := wrapped.Accept()
+				assert.ErrorIs(t, err, tt.err)
+				assert.Empty(t, acceptErrors)
+				return
+			}
+
+			go func() {
+				conn, err := tls.Dial("tcp", ln.Addr().String(), &tls.Config{InsecureSkipVerify: true}) // #nosec G402 -- self-signed test cert
+				if err == nil {
+					_ = conn.Close()
+				}
+			}()
+
+			// The errors go to AcceptError, and Accept only returns the
+			// connection
+			conn, err := wrapped.Accept()
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+			assert.Len(t, acceptErrors, 2)
+			assert.ErrorIs(t, <-acceptErrors, tt.err)
+		})
+	}
+}
+
+func TestGetHelloFromContext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	wrapped := interceptls.NewInterceptListener(ln, &tls.Config{})
+	defer wrapped.Close() // nolint:errcheck
+
+	accept := func(record []byte) interceptls.HelloInfo {
+		t.Helper()
+		client, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer client.Close() // nolint:errcheck
+		_, err = client.Write(record)
+		require.NoError(t, err)
+
+		conn, err := wrapped.Accept()
+		require.NoError(t, err)
+		defer conn.Close() // nolint:errcheck
+		info, ok := interceptls.GetHelloFromContext(interceptls.ConnContextHandler(context.Background(), conn))
+		require.True(t, ok)
+		return info
+	}
+
+	// A record which claims to be a ClientHello, but is truncated
+	malformed := []byte{0x16, 0x03, 0x01, 0x00, 0x06, 0x01, 0x00, 0x01, 0xfc, 0x03, 0x03}
+	info := accept(malformed)
+	assert.Error(t, info.Err)
+	assert.Equal(t, malformed, info.Raw)
+	assert.Empty(t, info.Fingerprint.Ciphersuite)
+
+	hello, err := (&dactyloscopy.Fingerprint{
+		MessageType:      dactyloscopy.HandshakeType,
+		RecordTLSVersion: dactyloscopy.VersionTLS10,
+		TLSVersion:       dactyloscopy.VersionTLS12,
+		Ciphersuite:      []uint16{0xc02b, 0xc02f},
+		Compression:      []uint8{0},
+		Extensions:       []uint16{0x000a, 0x000b},
+		ECurves:          []uint16{0x001d, 0x0017},
+		EcPointFmt:       []uint8{0},
+	}).MarshalClientHello()
+	require.NoError(t, err)
+	info = accept(hello)
+	assert.NoError(t, info.Err)
+	assert.Equal(t, hello, info.Raw)
+	assert.NotEmpty(t, info.Fingerprint.Ciphersuite)
+
+	// Not an interceptls connection at all
+	_, ok := interceptls.GetHelloFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestInterceptListenerGetConfigForClient(t *testing.T) {
+	legacyCert, modernCert := loadTestCert(t), loadTestCert(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	wrapped := interceptls.NewInterceptListener(ln, &tls.Config{Certificates: []tls.Certificate{modernCert}})
+	legacy := &tls.Config{Certificates: []tls.Certificate{legacyCert}, MaxVersion: tls.VersionTLS12}
+	modern := &tls.Config{Certificates: []tls.Certificate{modernCert}, MinVersion: tls.VersionTLS13}
+	wrapped.GetConfigForClient = func(fp *dactyloscopy.Fingerprint, info *tls.ClientHelloInfo) (*tls.Config, error) {
+		if slices.Contains(fp.SupportedVersions, tls.VersionTLS13) {
+			return modern, nil
+		}
+		if fp.SNI == "error.example" {
+			return nil, errors.New("no config")
+		}
+		return legacy, nil
+	}
+	server := &http.Server{
+		ConnContext: interceptls.ConnContextHandler,
+		Handler:     http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	}
+	go server.Serve(wrapped) // nolint:errcheck
+	defer server.Close()     // nolint:errcheck
+
+	dial := func(config *tls.Config) (tls.ConnectionState, error) {
+		config.InsecureSkipVerify = true // #nosec G402 -- self-signed test cert
+		conn, err := tls.Dial("tcp", ln.Addr().String(), config)
+		if err != nil {
+			return tls.ConnectionState{}, err
+		}
+		defer conn.Close() // nolint:errcheck
+		return conn.ConnectionState(), nil
+	}
+
+	state, err := dial(&tls.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), state.Version)
+	assert.Equal(t, modernCert.Certificate[0], state.PeerCertificates[0].Raw)
+
+	state, err = dial(&tls.Config{MaxVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), state.Version)
+	assert.Equal(t, legacyCert.Certificate[0], state.PeerCertificates[0].Raw)
+
+	_, err = dial(&tls.Config{MaxVersion: tls.VersionTLS12, ServerName: "error.example"})
+	assert.Error(t, err)
+}
+
 func generateSelfSignedCert(t *testing.T) (certPEM, keyPEM []byte, err error) {
 	t.Helper()
 	log.Printf("Generating self-signed certificate...")
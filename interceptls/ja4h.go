@@ -0,0 +1,108 @@
+package interceptls
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ja4hEmpty is the hash section used when there is nothing to hash
+const ja4hEmpty = "000000000000"
+
+// JA4H calculates the JA4H HTTP client fingerprint of a request:
+//
+//	<method><version><cookie><referer><header count><language>_<headers>_<cookie names>_<cookies>
+//
+// net/http doesn't keep the order of the headers, so they are hashed in sorted
+// order, which won't match JA4H from tools which see the raw request.
+// GetFingerprintFromRequest uses the order the client sent, where it was
+// captured
+func JA4H(r *http.Request) string {
+	names := make([]string, 0, len(r.Header)+1)
+	if r.ProtoMajor < 2 && r.Host != "" {
+		// net/http moves Host out of the headers, HTTP/2 sends it as the
+		// :authority pseudo-header which isn't counted
+		names = append(names, "Host")
+	}
+	for name := range r.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return ja4h(r, names)
+}
+
+// ja4h calculates JA4H using the given header names, in the order they were
+// sent
+func ja4h(r *http.Request, names []string) string {
+	method := strings.ToLower(r.Method)
+	if len(method) > 2 {
+		method = method[:2]
+	}
+
+	version := fmt.Sprintf("%d%d", r.ProtoMajor, r.ProtoMinor)
+	if r.ProtoMajor >= 2 {
+		version = fmt.Sprintf("%d0", r.ProtoMajor)
+	}
+
+	var headers []string
+	cookie, referer := "n", "n"
+	for _, name := range names {
+		switch {
+		case strings.EqualFold(name, "Cookie"):
+			cookie = "c"
+		case strings.EqualFold(name, "Referer"):
+			referer = "r"
+		case strings.HasPrefix(name, ":"):
+			// HTTP/2 pseudo-headers aren't counted
+		default:
+			headers = append(headers, name)
+		}
+	}
+
+	a := fmt.Sprintf("%s%s%s%s%02d%s", method, version, cookie, referer, min(len(headers), 99), ja4hLanguage(r.Header.Get("Accept-Language")))
+
+	var cookieNames, cookieValues []string
+	for _, c := range r.Cookies() {
+		cookieNames = append(cookieNames, c.Name)
+		cookieValues = append(cookieValues, c.Name+"="+c.Value)
+	}
+	sort.Strings(cookieNames)
+	sort.Strings(cookieValues)
+
+	return strings.Join([]string{
+		a,
+		ja4hHash(headers),
+		ja4hHash(cookieNames),
+		ja4hHash(cookieValues),
+	}, "_")
+}
+
+// ja4hLanguage returns the first 4 letters of the primary language, e.g. enus
+// for "en-US,en;q=0.9", padded with 0
+func ja4hLanguage(accept string) string {
+	primary, _, _ := strings.Cut(accept, ",")
+	primary, _, _ = strings.Cut(primary, ";")
+	var lang strings.Builder
+	for _, c := range strings.ToLower(primary) {
+		if lang.Len() == 4 {
+			break
+		}
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			lang.WriteRune(c)
+		}
+	}
+	return lang.String() + strings.Repeat("0", 4-lang.Len())
+}
+
+// ja4hHash is the first 12 hex characters of the SHA256 of the comma separated
+// values
+func ja4hHash(values []string) string {
+	if len(values) == 0 {
+		return ja4hEmpty
+	}
+	sum := sha256.Sum256([]byte(strings.Join(values, ",")))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,41 @@
+package interceptls_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+func sha12(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func TestJA4H(t *testing.T) {
+	r := httptest.NewRequest("GET", "https://example.com/", nil)
+	r.Header.Set("User-Agent", "test")
+	r.Header.Set("Accept", "*/*")
+	r.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	r.Header.Set("Cookie", "b=2; a=1")
+	r.Header.Set("Referer", "https://example.com/from")
+
+	assert.Equal(t,
+		"ge11cr04enus_"+sha12("Accept,Accept-Language,Host,User-Agent")+"_"+sha12("a,b")+"_"+sha12("a=1,b=2"),
+		interceptls.JA4H(r))
+}
+
+func TestJA4HMinimal(t *testing.T) {
+	r := httptest.NewRequest("POST", "https://example.com/", nil)
+	r.Proto, r.ProtoMajor, r.ProtoMinor = "HTTP/2.0", 2, 0
+
+	// No headers at all, HTTP/2 doesn't count the authority
+	assert.Equal(t, "po20nn000000_000000000000_000000000000_000000000000", interceptls.JA4H(r))
+
+	r.Header.Set("Accept-Language", "de")
+	assert.Equal(t, "po20nn01de00_"+sha12("Accept-Language")+"_000000000000_000000000000", interceptls.JA4H(r))
+}
@@ -0,0 +1,198 @@
+package interceptls
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+// ErrNoRoute is recorded for connections which Route has no backend for
+var ErrNoRoute = errors.New("interceptls: no route")
+
+// ErrPassthroughClosed is returned by Serve after Close
+var ErrPassthroughClosed = errors.New("interceptls: passthrough closed")
+
+// PassthroughRecord describes a connection through a Passthrough, once it
+// has ended
+type PassthroughRecord struct {
+	Remote  net.Addr
+	Backend string
+	// Fingerprint is empty if the ClientHello couldn't be read or parsed
+	Fingerprint dactyloscopy.Fingerprint
+	HelloErr    error
+	// BytesUp were sent by the client to the backend, including the
+	// ClientHello, BytesDown by the backend to the client
+	BytesUp   int64
+	BytesDown int64
+	Start     time.Time
+	Duration  time.Duration
+	// Err is why the connection couldn't be proxied, or ended abnormally
+	Err error
+}
+
+// Passthrough is a TCP proxy for TLS which fingerprints each client and routes
+// it to a backend by its ClientHello, without terminating TLS, for services
+// whose private keys it can't hold
+type Passthrough struct {
+	// Route returns the backend address for a client, see RouteBySNI.  If it
+	// isn't set every client is dropped with ErrNoRoute
+	Route func(hello HelloInfo, addr net.Addr) (string, error)
+	// Dial connects to backends, defaults to a net.Dialer with a 10s timeout
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+	// HelloTimeout bounds how long a client has to send its ClientHello.
+	// Defaults to DefaultHelloTimeout, a negative value waits forever
+	HelloTimeout time.Duration
+	// AcceptError, if set, is called with each temporary error from the
+	// listener, such as running out of file descriptors, which is retried
+	// with a backoff
+	AcceptError func(err error)
+	// Log, if set, is called once each connection has ended
+	Log func(PassthroughRecord)
+
+	listeners listenerSet
+}
+
+// ListenAndServe listens on the TCP address and proxies connections to it
+func (p *Passthrough) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return p.Serve(l)
+}
+
+// Serve proxies connections from the listener until it is closed
+func (p *Passthrough) Serve(l net.Listener) error {
+	return p.listeners.serve(l, ErrPassthroughClosed, p.ServeConn, p.AcceptError)
+}
+
+// Close stops the proxy accepting new connections.  Connections already being
+// proxied carry on until they end
+func (p *Passthrough) Close() error {
+	return p.listeners.close()
+}
+
+// ServeConn proxies a single connection, closing it once done
+func (p *Passthrough) ServeConn(conn net.Conn) {
+	record := PassthroughRecord{Remote: conn.RemoteAddr(), Start: time.Now()}
+	defer func() {
+		_ = conn.Close()
+		record.Duration = time.Since(record.Start)
+		if p.Log != nil {
+			p.Log(record)
+		}
+	}()
+
+	if p.HelloTimeout >= 0 {
+		if record.Err = conn.SetReadDeadline(time.Now().Add(cmp.Or(p.HelloTimeout, DefaultHelloTimeout))); record.Err != nil {
+			return
+		}
+	}
+	peeked, err := peekClientHello(conn)
+	if err != nil {
+		record.Err = fmt.Errorf("peekClientHello: %w", err)
+		return
+	}
+	if record.Err = conn.SetReadDeadline(time.Time{}); record.Err != nil {
+		return
+	}
+	record.Fingerprint, record.HelloErr = extractFP(peeked)
+
+	hello := HelloInfo{Fingerprint: record.Fingerprint, Raw: peeked, Err: record.HelloErr}
+	route := p.Route
+	if route == nil {
+		route = RouteBySNI(nil, "")
+	}
+	if record.Backend, record.Err = route(hello, conn.RemoteAddr()); record.Err != nil {
+		return
+	}
+	if record.Backend == "" {
+		record.Err = ErrNoRoute
+		return
+	}
+
+	dial := p.Dial
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: 10 * time.Second}).DialContext
+	}
+	backend, err := dial(context.Background(), "tcp", record.Backend)
+	if err != nil {
+		record.Err = err
+		return
+	}
+	defer backend.Close() // nolint:errcheck
+
+	// The peeked ClientHello is replayed, then the client's half is copied
+	// straight from the connection so the kernel can splice it
+	record.BytesUp, record.BytesDown, record.Err = pipe(conn, backend, func() (int64, error) {
+		written, err := backend.Write(peeked)
+		if err != nil {
+			return int64(written), err
+		}
+		n, err := io.Copy(backend, conn)
+		return int64(written) + n, err
+	})
+}
+
+// pipe copies from backend to client, while up copies the other way, until
+// both are done.  Each direction is half-closed as it finishes
+func pipe(client, backend net.Conn, up func() (int64, error)) (int64, int64, error) {
+	type result struct {
+		n   int64
+		err error
+	}
+	upDone := make(chan result, 1)
+	go func() {
+		n, err := up()
+		closeWrite(backend)
+		upDone <- result{n, err}
+	}()
+	down, downErr := io.Copy(client, backend)
+	closeWrite(client)
+	upResult := <-upDone
+	return upResult.n, down, cmp.Or(upResult.err, downErr)
+}
+
+// closeWrite half-closes the connection where possible, so the other end sees
+// EOF while data can still flow back
+func closeWrite(conn net.Conn) {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+		return
+	}
+	_ = conn.Close()
+}
+
+// RouteBySNI returns a Passthrough.Route which looks the SNI up in routes,
+// falling back to a wildcard entry such as "*.example.com", then to fallback.
+// As in certificates, a wildcard covers a single label, so "*.example.com"
+// matches "www.example.com" but neither "example.com" nor "a.b.example.com".
+// Clients with no route are dropped if fallback is empty
+func RouteBySNI(routes map[string]string, fallback string) func(HelloInfo, net.Addr) (string, error) {
+	lower := make(map[string]string, len(routes))
+	for sni, backend := range routes {
+		lower[strings.ToLower(sni)] = backend
+	}
+	return func(hello HelloInfo, _ net.Addr) (string, error) {
+		sni := strings.ToLower(hello.Fingerprint.SNI)
+		if backend, ok := lower[sni]; ok && sni != "" {
+			return backend, nil
+		}
+		if label, parent, ok := strings.Cut(sni, "."); ok && label != "" && parent != "" {
+			if backend, ok := lower["*."+parent]; ok {
+				return backend, nil
+			}
+		}
+		if fallback == "" {
+			return "", ErrNoRoute
+		}
+		return fallback, nil
+	}
+}
@@ -0,0 +1,159 @@
+package interceptls_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+func TestPassthrough(t *testing.T) {
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello from "+r.TLS.ServerName)
+	}))
+	defer backend.Close()
+
+	records := make(chan interceptls.PassthroughRecord, 2)
+	proxy := &interceptls.Passthrough{
+		Route: interceptls.RouteBySNI(map[string]string{"*.example.com": backend.Listener.Addr().String()}, ""),
+		Log:   func(r interceptls.PassthroughRecord) { records <- r },
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go proxy.Serve(ln)  // nolint:errcheck
+	defer proxy.Close() // nolint:errcheck
+
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true, ServerName: "www.example.com"}, // #nosec G402 -- self-signed test cert
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, ln.Addr().String())
+		},
+	}
+	client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+	resp, err := client.Get("https://www.example.com/")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	// The backend terminated TLS, not the proxy
+	assert.Equal(t, "hello from www.example.com", string(body))
+	transport.CloseIdleConnections()
+
+	record := <-records
+	assert.NoError(t, record.Err)
+	assert.NoError(t, record.HelloErr)
+	assert.Equal(t, backend.Listener.Addr().String(), record.Backend)
+	assert.Equal(t, "www.example.com", record.Fingerprint.SNI)
+	assert.NotEmpty(t, record.Fingerprint.JA4)
+	assert.Positive(t, record.BytesUp)
+	assert.Positive(t, record.BytesDown)
+	assert.Positive(t, record.Duration)
+
+	// Clients with no route are dropped
+	_, err = tls.Dial("tcp", ln.Addr().String(), &tls.Config{ServerName: "other.test", InsecureSkipVerify: true}) // #nosec G402 -- self-signed test cert
+	assert.Error(t, err)
+	record = <-records
+	assert.ErrorIs(t, record.Err, interceptls.ErrNoRoute)
+	assert.Equal(t, "other.test", record.Fingerprint.SNI)
+	assert.Zero(t, record.BytesUp)
+}
+
+func TestRouteBySNI(t *testing.T) {
+	route := interceptls.RouteBySNI(map[string]string{
+		"Example.com":       "exact:443",
+		"*.example.com":     "wildcard:443",
+		"*.api.example.com": "api:443",
+	}, "fallback:443")
+
+	for sni, want := range map[string]string{
+		"example.com":        "exact:443",
+		"www.EXAMPLE.com":    "wildcard:443",
+		"a.b.example.com":    "fallback:443",
+		"v1.api.example.com": "api:443",
+		"example.org":        "fallback:443",
+		"":                   "fallback:443",
+		".example.com":       "fallback:443",
+	} {
+		hello := interceptls.HelloInfo{}
+		hello.Fingerprint.SNI = sni
+		got, err := route(hello, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, sni)
+	}
+
+	_, err := interceptls.RouteBySNI(nil, "")(interceptls.HelloInfo{}, nil)
+	assert.ErrorIs(t, err, interceptls.ErrNoRoute)
+}
+
+func TestPassthroughNoRoute(t *testing.T) {
+	var record interceptls.PassthroughRecord
+	client, server := net.Pipe()
+	go func() {
+		_ = tls.Client(client, &tls.Config{ServerName: "www.example.com"}).Handshake()
+	}()
+	(&interceptls.Passthrough{Log: func(r interceptls.PassthroughRecord) { record = r }}).ServeConn(server)
+	assert.ErrorIs(t, record.Err, interceptls.ErrNoRoute)
+	assert.Equal(t, "www.example.com", record.Fingerprint.SNI)
+}
+
+func TestPassthroughAcceptError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantRetry bool
+	}{
+		{"timeout", os.ErrDeadlineExceeded, true},
+		{"out of file descriptors", &net.OpError{Op: "accept", Net: "tcp", Err: os.NewSyscallError("accept4", syscall.EMFILE)}, true},
+		{"permanent", errors.New("listener broken"), false},
+		{"closed", net.ErrClosed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			acceptErrors := make(chan error, 2)
+			logged := make(chan interceptls.PassthroughRecord, 1)
+			p := &interceptls.Passthrough{
+				AcceptError: func(err error) { acceptErrors <- err },
+				Log:         func(r interceptls.PassthroughRecord) { logged <- r },
+			}
+			served := make(chan error, 1)
+			go func() { served <- p.Serve(&flakyListener{Listener: ln, err: tt.err, failures: 2}) }()
+
+			if !tt.wantRetry {
+				assert.ErrorIs(t, <-served, tt.err)
+				assert.Empty(t, acceptErrors)
+				require.NoError(t, ln.Close())
+				return
+			}
+
+			// Serve carries on after the errors, and handles the next client
+			client, err := net.Dial("tcp", ln.Addr().String())
+			require.NoError(t, err)
+			require.NoError(t, client.Close())
+			select {
+			case <-logged:
+			case <-time.After(5 * time.Second):
+				t.Fatal("connection wasn't served")
+			}
+			assert.Len(t, acceptErrors, 2)
+			assert.ErrorIs(t, <-acceptErrors, tt.err)
+
+			require.NoError(t, p.Close())
+			assert.ErrorIs(t, <-served, interceptls.ErrPassthroughClosed)
+		})
+	}
+}
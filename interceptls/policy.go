@@ -0,0 +1,58 @@
+package interceptls
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// PolicyAction is what the listener does with a connection, once its
+// ClientHello has been read
+type PolicyAction int
+
+const (
+	// PolicyAccept carries on with the TLS handshake as normal
+	PolicyAccept PolicyAction = iota
+	// PolicyClose closes the connection
+	PolicyClose
+	// PolicyReset closes the connection with a TCP RST
+	PolicyReset
+	// PolicyDelay tarpits the connection, holding it open without responding
+	// for PolicyDecision.Delay, then closes it
+	PolicyDelay
+	// PolicyTLSConfig carries on with the TLS handshake using
+	// PolicyDecision.TLSConfig in place of the listener's
+	PolicyTLSConfig
+)
+
+func (a PolicyAction) String() string {
+	switch a {
+	case PolicyAccept:
+		return "accept"
+	case PolicyClose:
+		return "close"
+	case PolicyReset:
+		return "reset"
+	case PolicyDelay:
+		return "delay"
+	case PolicyTLSConfig:
+		return "tls_config"
+	}
+	return "unknown"
+}
+
+// PolicyDecision is the result of a Policy, the zero value accepts
+type PolicyDecision struct {
+	Action PolicyAction
+	// Delay is how long PolicyDelay holds the connection
+	Delay time.Duration
+	// TLSConfig is used for the handshake by PolicyTLSConfig, e.g. to serve a
+	// decoy certificate.  It is used as is, so should set NextProtos to offer
+	// h2
+	TLSConfig *tls.Config
+}
+
+// Policy decides what to do with a connection from its ClientHello, before any
+// CPU is spent on the handshake.  It is called from the connection's own
+// goroutine, so may block, e.g. to slow a client down before accepting it
+type Policy func(hello HelloInfo, addr net.Addr) PolicyDecision
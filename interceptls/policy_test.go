@@ -0,0 +1,80 @@
+package interceptls_test
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+func loadTestCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	certPEM, keyPEM, err := generateSelfSignedCert(t)
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestPolicy(t *testing.T) {
+	cert, decoy := loadTestCert(t), loadTestCert(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	wrapped := interceptls.NewInterceptListener(ln, &tls.Config{Certificates: []tls.Certificate{cert}})
+	wrapped.Policy = func(hello interceptls.HelloInfo, addr net.Addr) interceptls.PolicyDecision {
+		switch hello.Fingerprint.SNI {
+		case "close.example":
+			return interceptls.PolicyDecision{Action: interceptls.PolicyClose}
+		case "reset.example":
+			return interceptls.PolicyDecision{Action: interceptls.PolicyReset}
+		case "delay.example":
+			return interceptls.PolicyDecision{Action: interceptls.PolicyDelay, Delay: 200 * time.Millisecond}
+		case "decoy.example":
+			return interceptls.PolicyDecision{Action: interceptls.PolicyTLSConfig, TLSConfig: &tls.Config{Certificates: []tls.Certificate{decoy}}}
+		}
+		return interceptls.PolicyDecision{}
+	}
+	server := &http.Server{
+		ConnContext: interceptls.ConnContextHandler,
+		Handler:     http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	}
+	go server.Serve(wrapped) // nolint:errcheck
+	defer server.Close()     // nolint:errcheck
+
+	dial := func(sni string) (*tls.Conn, error) {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: sni, InsecureSkipVerify: true}) // #nosec G402 -- self-signed test cert
+		require.NoError(t, tlsConn.SetDeadline(time.Now().Add(5*time.Second)))
+		return tlsConn, tlsConn.Handshake()
+	}
+
+	conn, err := dial("accept.example")
+	require.NoError(t, err)
+	assert.Equal(t, cert.Certificate[0], conn.ConnectionState().PeerCertificates[0].Raw)
+	_ = conn.Close()
+
+	conn, err = dial("decoy.example")
+	require.NoError(t, err)
+	assert.Equal(t, decoy.Certificate[0], conn.ConnectionState().PeerCertificates[0].Raw)
+	_ = conn.Close()
+
+	_, err = dial("close.example")
+	assert.Error(t, err)
+
+	_, err = dial("reset.example")
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+
+	start := time.Now()
+	_, err = dial("delay.example")
+	assert.Error(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
@@ -0,0 +1,204 @@
+package interceptls
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultCaptureSize is the number of bytes of plaintext captured from the
+// start of each connection, enough for the header block of any reasonable
+// request
+const DefaultCaptureSize = 16 << 10
+
+// http2Preface starts every HTTP/2 connection
+const http2Preface = "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"
+
+// HeaderField is a single header, with the name as the client sent it
+type HeaderField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// RawHeaders is the header block of the first request on a connection, as the
+// client sent it.  net/http canonicalises header names and stores them in a
+// map, losing the order and casing, both of which differ between clients
+type RawHeaders struct {
+	RequestLine string        `json:"request_line"`
+	Fields      []HeaderField `json:"fields"`
+	// OrderHash is the first 12 hex characters of the SHA256 of the header
+	// names, in order and as sent, separated by commas
+	OrderHash string `json:"order_hash"`
+	// Truncated is set when the header block was larger than the capture,
+	// in which case Fields only holds the headers which fitted
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Names returns the header names in the order they were sent
+func (h *RawHeaders) Names() []string {
+	names := make([]string, len(h.Fields))
+	for i, f := range h.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// plaintextCapture records the start of the decrypted stream read from a
+// connection.  Capturing stops as soon as the HTTP/1.x header block, or the
+// HTTP/2 headers of the first request, are complete, so request bodies are
+// never buffered
+type plaintextCapture struct {
+	mu    sync.Mutex
+	limit int
+	buf   []byte
+	done  bool
+	raw   *RawHeaders
+
+	// h2 is set once the HTTP/2 preface has been seen, parsed counts the
+	// bytes of frames it has already read
+	h2     *http2Parser
+	parsed int
+}
+
+func newPlaintextCapture(limit int) *plaintextCapture {
+	if limit <= 0 {
+		limit = DefaultCaptureSize
+	}
+	return &plaintextCapture{limit: limit}
+}
+
+func (c *plaintextCapture) write(b []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return
+	}
+	room := c.limit - len(c.buf)
+	if len(b) > room {
+		b = b[:room]
+	}
+	c.buf = append(c.buf, b...)
+
+	switch {
+	case c.h2 != nil:
+		c.writeHTTP2()
+	case len(c.buf) < len(http2Preface) && strings.HasPrefix(http2Preface, string(c.buf)):
+		// Could still be HTTP/2, wait for more
+	case bytes.HasPrefix(c.buf, []byte(http2Preface)):
+		c.h2 = &http2Parser{}
+		c.parsed = len(http2Preface)
+		c.writeHTTP2()
+	default:
+		if end := headerBlockEnd(c.buf); end >= 0 {
+			c.raw = parseRawHeaders(c.buf[:end], false)
+			c.finish()
+		} else if len(c.buf) >= c.limit {
+			c.raw = parseRawHeaders(c.buf, true)
+			c.finish()
+		}
+	}
+}
+
+// writeHTTP2 reads any newly complete frames
+func (c *plaintextCapture) writeHTTP2() {
+	n, done := c.h2.parse(c.buf[c.parsed:])
+	c.parsed += n
+	if !done && len(c.buf) >= c.limit {
+		c.h2.fp.Truncated = true
+		done = true
+	}
+	if done {
+		c.finish()
+	}
+}
+
+// finish stops capturing, and releases the buffer
+func (c *plaintextCapture) finish() {
+	c.done = true
+	c.buf = nil
+}
+
+// rawHeaders returns the captured header block, if it is complete (or as
+// complete as it will get)
+func (c *plaintextCapture) rawHeaders() (*RawHeaders, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.raw, c.raw != nil
+}
+
+// http2Fingerprint returns the HTTP/2 fingerprint, once the first request's
+// headers have been read
+func (c *plaintextCapture) http2Fingerprint() (*HTTP2Fingerprint, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.h2 == nil || !c.done {
+		return nil, false
+	}
+	fp := c.h2.fp
+	return &fp, true
+}
+
+// headerBlockEnd returns the length of the request line and headers,
+// excluding the blank line which ends them, or -1 if the block is incomplete
+func headerBlockEnd(buf []byte) int {
+	end := -1
+	if i := bytes.Index(buf, []byte("\r\n\r\n")); i >= 0 {
+		end = i
+	}
+	// net/http also accepts bare newlines
+	if i := bytes.Index(buf, []byte("\n\n")); i >= 0 && (end < 0 || i < end) {
+		end = i
+	}
+	return end
+}
+
+func parseRawHeaders(block []byte, truncated bool) *RawHeaders {
+	lines := strings.Split(strings.ReplaceAll(string(block), "\r\n", "\n"), "\n")
+	if truncated && len(lines) > 1 {
+		// The last line is probably incomplete
+		lines = lines[:len(lines)-1]
+	}
+	raw := &RawHeaders{RequestLine: lines[0], Truncated: truncated}
+	for _, line := range lines[1:] {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(raw.Fields) > 0 {
+			// Obsolete line folding continues the previous value
+			last := &raw.Fields[len(raw.Fields)-1]
+			last.Value += " " + strings.TrimSpace(line)
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		raw.Fields = append(raw.Fields, HeaderField{Name: name, Value: strings.TrimSpace(value)})
+	}
+	raw.OrderHash = ja4hHash(raw.Names())
+	return raw
+}
+
+// GetRawHeadersFromRequest returns the header block as the client sent it.
+// Only the first request on a connection is captured, so this returns false
+// for later requests on a keep-alive connection, for HTTP/2, and for requests
+// which didn't arrive through an intercepting listener.
+func GetRawHeadersFromRequest(r *http.Request) (*RawHeaders, bool) {
+	hc, ok := r.Context().Value(helloConnKey).(*HelloConn)
+	if !ok || hc.capture == nil {
+		return nil, false
+	}
+	raw, ok := hc.capture.rawHeaders()
+	if !ok || !matchesRequestLine(raw.RequestLine, r) {
+		return nil, false
+	}
+	return raw, true
+}
+
+// matchesRequestLine checks that the captured request line is for this
+// request, rather than an earlier one on the same connection.  Identical
+// requests can't be told apart, but their headers are very likely the same
+func matchesRequestLine(line string, r *http.Request) bool {
+	return line == r.Method+" "+r.RequestURI+" "+r.Proto
+}
@@ -0,0 +1,132 @@
+package interceptls_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy/interceptls"
+)
+
+// startInterceptServer serves handler over an intercepting listener, returning
+// its address.  captureSize is passed to the listener, 0 for the default
+func startInterceptServer(t *testing.T, handler http.Handler, captureSize int) string {
+	t.Helper()
+	certPEM, keyPEM, err := generateSelfSignedCert(t)
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	wrapped := interceptls.NewInterceptListener(ln, &tls.Config{Certificates: []tls.Certificate{cert}, NextProtos: []string{"h2", "http/1.1"}})
+	wrapped.CaptureSize = captureSize
+
+	server := &http.Server{ConnContext: interceptls.ConnContextHandler, Handler: handler}
+	go server.Serve(wrapped) // nolint:errcheck
+	t.Cleanup(func() { _ = server.Close() })
+	return ln.Addr().String()
+}
+
+type rawHeadersResponse struct {
+	Found  bool                    `json:"found"`
+	Raw    *interceptls.RawHeaders `json:"raw"`
+	Body   int                     `json:"body"`
+	JA4H   string                  `json:"ja4h"`
+	Sorted string                  `json:"sorted"`
+}
+
+func rawHeadersHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	raw, found := interceptls.GetRawHeadersFromRequest(r)
+	fp := interceptls.GetFingerprintFromRequest(w, r)
+	_ = json.NewEncoder(w).Encode(rawHeadersResponse{
+		Found:  found,
+		Raw:    raw,
+		Body:   len(body),
+		JA4H:   fp.JA4H,
+		Sorted: interceptls.JA4H(r),
+	})
+}
+
+// rawRequest sends the requests as written over a single TLS connection,
+// returning the decoded responses
+func rawRequest(t *testing.T, addr string, requests ...string) []rawHeadersResponse {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true}) // #nosec G402 -- self-signed test cert
+	require.NoError(t, err)
+	defer conn.Close() // nolint:errcheck
+
+	reader := bufio.NewReader(conn)
+	var responses []rawHeadersResponse
+	for _, req := range requests {
+		_, err = io.WriteString(conn, req)
+		require.NoError(t, err)
+		resp, err := http.ReadResponse(reader, nil)
+		require.NoError(t, err)
+		var decoded rawHeadersResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		require.NoError(t, resp.Body.Close())
+		responses = append(responses, decoded)
+	}
+	return responses
+}
+
+func TestRawHeaders(t *testing.T) {
+	addr := startInterceptServer(t, http.HandlerFunc(rawHeadersHandler), 0)
+
+	body := strings.Repeat("x", 100000)
+	responses := rawRequest(t, addr,
+		"POST /upload?a=1 HTTP/1.1\r\n"+
+			"host: example.com\r\n"+
+			"user-agent: curl/8.0\r\n"+
+			"X-Folded: one\r\n two\r\n"+
+			"accept: */*\r\n"+
+			fmt.Sprintf("Content-Length: %d\r\n\r\n", len(body))+body,
+		"GET /second HTTP/1.1\r\nHost: example.com\r\n\r\n",
+	)
+
+	first := responses[0]
+	require.True(t, first.Found)
+	assert.Equal(t, "POST /upload?a=1 HTTP/1.1", first.Raw.RequestLine)
+	assert.Equal(t, []interceptls.HeaderField{
+		{Name: "host", Value: "example.com"},
+		{Name: "user-agent", Value: "curl/8.0"},
+		{Name: "X-Folded", Value: "one two"},
+		{Name: "accept", Value: "*/*"},
+		{Name: "Content-Length", Value: "100000"},
+	}, first.Raw.Fields)
+	assert.Equal(t, sha12("host,user-agent,X-Folded,accept,Content-Length"), first.Raw.OrderHash)
+	assert.False(t, first.Raw.Truncated)
+	// The body isn't affected by the capture
+	assert.Equal(t, len(body), first.Body)
+	// JA4H uses the order as sent, when it is available
+	assert.Contains(t, first.JA4H, "_"+sha12("host,user-agent,X-Folded,accept,Content-Length")+"_")
+	assert.NotEqual(t, first.Sorted, first.JA4H)
+
+	// Only the first request on the connection is captured
+	second := responses[1]
+	assert.False(t, second.Found)
+	assert.Equal(t, second.Sorted, second.JA4H)
+}
+
+func TestRawHeadersTruncated(t *testing.T) {
+	addr := startInterceptServer(t, http.HandlerFunc(rawHeadersHandler), 64)
+
+	responses := rawRequest(t, addr, "GET / HTTP/1.1\r\nHost: example.com\r\nUser-Agent: test\r\nX-Long: "+strings.Repeat("y", 200)+"\r\n\r\n")
+	require.True(t, responses[0].Found)
+	assert.True(t, responses[0].Raw.Truncated)
+	assert.Equal(t, []interceptls.HeaderField{
+		{Name: "Host", Value: "example.com"},
+		{Name: "User-Agent", Value: "test"},
+	}, responses[0].Raw.Fields)
+}
@@ -0,0 +1,76 @@
+package interceptls
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// listenerSet tracks the listeners a proxy is serving, so that closing the
+// proxy stops them all.  Passthrough and ForwardProxy share it
+type listenerSet struct {
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	closed    bool
+}
+
+// serve accepts connections from l, handling each in its own goroutine, until
+// the set is closed, when it returns errClosed, or l fails permanently.
+// Temporary errors, such as running out of file descriptors, are passed to
+// acceptError if it is set, and retried with a backoff as http.Server does
+func (s *listenerSet) serve(l net.Listener, errClosed error, handle func(net.Conn), acceptError func(error)) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return errClosed
+	}
+	if s.listeners == nil {
+		s.listeners = map[net.Listener]struct{}{}
+	}
+	s.listeners[l] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.listeners, l)
+		s.mu.Unlock()
+	}()
+
+	var delay time.Duration
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return errClosed
+			}
+			if !temporary(err) {
+				return err
+			}
+			if acceptError != nil {
+				acceptError(err)
+			}
+			delay = nextAcceptDelay(delay)
+			time.Sleep(delay)
+			continue
+		}
+		delay = 0
+		go handle(conn)
+	}
+}
+
+// close closes the listeners being served, and any passed to serve later
+func (s *listenerSet) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	var err error
+	for l := range s.listeners {
+		if cerr := l.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
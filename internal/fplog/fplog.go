@@ -0,0 +1,42 @@
+// Package fplog holds what the commands share for logging client
+// fingerprints: loading the fingerprint DB and the log attributes describing a
+// client
+package fplog
+
+import (
+	"log/slog"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+// LoadDB loads the fingerprint DB at path, see
+// dactyloscopy.LoadFingerprintDBFile, or returns an empty DB if path is empty
+func LoadDB(path string) (*dactyloscopy.FingerprintDB, error) {
+	if path == "" {
+		return dactyloscopy.NewFingerprintDB(nil), nil
+	}
+	return dactyloscopy.LoadFingerprintDBFile(path)
+}
+
+// Attrs are the log attributes describing a client: its SNI and hashes, the
+// name, matched_on and tags of its DB entry if it has one, and hello_error if
+// its ClientHello couldn't be parsed
+func Attrs(db *dactyloscopy.FingerprintDB, fp *dactyloscopy.Fingerprint, helloErr error) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("sni", fp.SNI),
+		slog.String("ja3", fp.JA3),
+		slog.String("ja3n", fp.JA3N),
+		slog.String("ja4", fp.JA4),
+	}
+	if match, ok := db.Lookup(fp); ok {
+		attrs = append(attrs,
+			slog.String("client", match.Name),
+			slog.String("matched_on", match.MatchedOn),
+			slog.Any("tags", match.Tags),
+		)
+	}
+	if helloErr != nil {
+		attrs = append(attrs, slog.String("hello_error", helloErr.Error()))
+	}
+	return attrs
+}
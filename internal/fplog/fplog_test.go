@@ -0,0 +1,63 @@
+package fplog_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/LeeBrotherston/dactyloscopy/internal/fplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttrs(t *testing.T) {
+	db := dactyloscopy.NewFingerprintDB([]dactyloscopy.DBEntry{
+		{Name: "curl", JA4: "t13d3112h2_e8f1e7e78f70_6bebaf5329ac", Tags: []string{"cli"}},
+	})
+
+	tests := []struct {
+		name     string
+		fp       dactyloscopy.Fingerprint
+		helloErr error
+		want     map[string]any
+	}{
+		{
+			name: "unknown client",
+			fp:   dactyloscopy.Fingerprint{SNI: "example.com", JA3: "a", JA3N: "b", JA4: "c"},
+			want: map[string]any{"sni": "example.com", "ja3": "a", "ja3n": "b", "ja4": "c"},
+		},
+		{
+			name: "DB match",
+			fp:   dactyloscopy.Fingerprint{JA4: "t13d3112h2_e8f1e7e78f70_6bebaf5329ac"},
+			want: map[string]any{
+				"sni": "", "ja3": "", "ja3n": "", "ja4": "t13d3112h2_e8f1e7e78f70_6bebaf5329ac",
+				"client": "curl", "matched_on": "ja4", "tags": []string{"cli"},
+			},
+		},
+		{
+			name:     "unparsable ClientHello",
+			helloErr: errors.New("truncated"),
+			want:     map[string]any{"sni": "", "ja3": "", "ja3n": "", "ja4": "", "hello_error": "truncated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]any{}
+			for _, attr := range fplog.Attrs(db, &tt.fp, tt.helloErr) {
+				got[attr.Key] = attr.Value.Any()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadDB(t *testing.T) {
+	db, err := fplog.LoadDB("")
+	require.NoError(t, err)
+	assert.Empty(t, db.Entries)
+
+	_, err = fplog.LoadDB(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
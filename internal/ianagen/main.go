@@ -0,0 +1,355 @@
+// Command ianagen builds the IANA registry tables used by dactyloscopy from
+// the CSV exports published at https://www.iana.org/assignments/tls-parameters
+// and https://www.iana.org/assignments/tls-extensiontype-values.
+//
+// To refresh the tables, run "go run ./internal/ianagen -fetch" in the package
+// root, which downloads the current CSVs over the copies in the registries
+// directory, then "go generate".  Only the registry files are downloaded, the
+// local-*.csv files carry codepoints which are widely deployed but were never
+// registered (ALPS, ESNI, early Kyber experiments), and registry entries take
+// precedence over these.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// entry is a single registry row, reduced to the fields we generate
+type entry struct {
+	value       uint16
+	key         string // only used for ALPN
+	name        string
+	dtlsOK      bool
+	recommended bool
+	deprecated  bool
+}
+
+func main() {
+	registries := flag.String("registries", "internal/ianagen/registries", "directory containing the registry CSV files")
+	out := flag.String("out", ".", "directory to write generated files to")
+	fetchFirst := flag.Bool("fetch", false, "download the current registry CSVs from IANA first")
+	flag.Parse()
+
+	if *fetchFirst {
+		if err := fetch(http.DefaultClient, ianaURLs, *registries); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := run(*registries, *out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// ianaURLs are where IANA publishes each registry file
+var ianaURLs = map[string]string{
+	"tls-parameters-4.csv":           "https://www.iana.org/assignments/tls-parameters/tls-parameters-4.csv",
+	"tls-parameters-8.csv":           "https://www.iana.org/assignments/tls-parameters/tls-parameters-8.csv",
+	"tls-signaturescheme.csv":        "https://www.iana.org/assignments/tls-parameters/tls-signaturescheme.csv",
+	"tls-extensiontype-values-1.csv": "https://www.iana.org/assignments/tls-extensiontype-values/tls-extensiontype-values-1.csv",
+	"alpn-protocol-ids.csv":          "https://www.iana.org/assignments/tls-extensiontype-values/alpn-protocol-ids.csv",
+}
+
+// fetch downloads each registry file into the registries directory.  Nothing
+// is written unless every download succeeds, so a failure leaves the copies
+// consistent
+func fetch(client *http.Client, urls map[string]string, registries string) error {
+	downloaded := map[string][]byte{}
+	for name, url := range urls {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", url, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("fetching %s: %s", url, resp.Status)
+		}
+		downloaded[name] = body
+	}
+	for name, body := range downloaded {
+		if err := os.WriteFile(filepath.Join(registries, name), body, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func run(registries, out string) error {
+	suites, err := readRegistry(filepath.Join(registries, "tls-parameters-4.csv"), parseSuiteValue, 1, 2, 3)
+	if err != nil {
+		return err
+	}
+	extensions, err := readRegistry(filepath.Join(registries, "tls-extensiontype-values-1.csv"), parseDecimalValue, 1, -1, 4)
+	if err != nil {
+		return err
+	}
+	localExtensions, err := readRegistry(filepath.Join(registries, "local-extensions.csv"), parseDecimalValue, 1, -1, 4)
+	if err != nil {
+		return err
+	}
+	groups, err := readRegistry(filepath.Join(registries, "tls-parameters-8.csv"), parseDecimalValue, 1, 2, 3)
+	if err != nil {
+		return err
+	}
+	localGroups, err := readRegistry(filepath.Join(registries, "local-groups.csv"), parseDecimalValue, 1, 2, 3)
+	if err != nil {
+		return err
+	}
+	schemes, err := readRegistry(filepath.Join(registries, "tls-signaturescheme.csv"), parseHexValue, 1, 2, 3)
+	if err != nil {
+		return err
+	}
+	alpn, err := readALPN(filepath.Join(registries, "alpn-protocol-ids.csv"))
+	if err != nil {
+		return err
+	}
+
+	extensions = merge(extensions, localExtensions)
+	for i := range extensions {
+		extensions[i].name = identifier(extensions[i].name)
+	}
+	groups = merge(groups, localGroups)
+
+	files := map[string][]byte{
+		"ianaTLSparams.go":           genUint16Map("ianaCiphersuites", ciphersuiteDoc, suites),
+		"ianaTLSExtensions.go":       genExtensions(extensions),
+		"ianaextension_string.go":    genExtensionStringer(),
+		"ianaTLSGroups.go":           genUint16Map("ianaSupportedGroups", groupDoc, groups),
+		"ianaTLSSignatureSchemes.go": genUint16Map("ianaSignatureSchemes", schemeDoc, schemes),
+		"ianaALPN.go":                genALPN(alpn),
+	}
+	for name, src := range files {
+		formatted, err := format.Source(src)
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w\n%s", name, err, src)
+		}
+		if err := os.WriteFile(filepath.Join(out, name), formatted, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readRegistry reads one of the standard IANA TLS registry CSV files.  Rows
+// describing ranges, or values which are reserved or unassigned, are skipped.
+// A dtlsCol of -1 indicates the registry has no DTLS-OK column.
+func readRegistry(path string, parseValue func(string) (uint16, bool), nameCol, dtlsCol, recCol int) ([]entry, error) {
+	rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	for _, row := range rows {
+		value, ok := parseValue(row[0])
+		if !ok {
+			continue
+		}
+		name := strings.TrimSpace(row[nameCol])
+		if skipName(name) {
+			continue
+		}
+		e := entry{value: value, name: name}
+		if dtlsCol >= 0 && dtlsCol < len(row) {
+			e.dtlsOK = row[dtlsCol] == "Y"
+		}
+		if recCol < len(row) {
+			e.recommended = row[recCol] == "Y"
+			e.deprecated = row[recCol] == "D"
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+var alpnSequence = regexp.MustCompile(`^((?:0x[0-9A-Fa-f]{2}\s*)+)`)
+
+// readALPN reads the ALPN Protocol IDs registry, whose values are written out
+// as a sequence of hex bytes followed by the quoted string
+func readALPN(path string) ([]entry, error) {
+	rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	for _, row := range rows {
+		if len(row) < 2 || skipName(row[0]) {
+			continue
+		}
+		match := alpnSequence.FindStringSubmatch(row[1])
+		if match == nil {
+			continue
+		}
+		raw, err := hex.DecodeString(strings.NewReplacer("0x", "", " ", "").Replace(strings.TrimSpace(match[1])))
+		if err != nil {
+			return nil, fmt.Errorf("bad ALPN identification sequence %q: %w", row[1], err)
+		}
+		entries = append(entries, entry{key: string(raw), name: strings.TrimSpace(row[0])})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	return entries, nil
+}
+
+func readCSV(path string) ([][]string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	// Drop the header
+	return rows[1:], nil
+}
+
+func skipName(name string) bool {
+	return name == "" || strings.HasPrefix(name, "Reserved") || strings.HasPrefix(name, "Unassigned")
+}
+
+// parseSuiteValue handles the "0xC0,0x2B" form used by the cipher suite registry
+func parseSuiteValue(s string) (uint16, bool) {
+	parts := strings.Split(strings.ReplaceAll(s, " ", ""), ",")
+	if len(parts) != 2 {
+		return 0, false
+	}
+	hi, err := strconv.ParseUint(parts[0], 0, 8)
+	if err != nil {
+		return 0, false
+	}
+	lo, err := strconv.ParseUint(parts[1], 0, 8)
+	if err != nil {
+		return 0, false
+	}
+	return uint16(hi<<8 | lo), true
+}
+
+func parseDecimalValue(s string) (uint16, bool) {
+	v, err := strconv.ParseUint(strings.TrimSpace(s), 10, 16)
+	return uint16(v), err == nil
+}
+
+func parseHexValue(s string) (uint16, bool) {
+	v, err := strconv.ParseUint(strings.TrimSpace(s), 0, 16)
+	return uint16(v), err == nil
+}
+
+// merge adds local entries to the registry entries where the value has not
+// been registered, returning the result sorted by value
+func merge(registry, local []entry) []entry {
+	seen := map[uint16]bool{}
+	for _, e := range registry {
+		seen[e.value] = true
+	}
+	for _, e := range local {
+		if !seen[e.value] {
+			registry = append(registry, e)
+		}
+	}
+	sort.Slice(registry, func(i, j int) bool { return registry[i].value < registry[j].value })
+	return registry
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// identifier turns a registry name such as "connection_id (deprecated)" into a
+// usable Go identifier, "connection_id_deprecated"
+func identifier(name string) string {
+	return strings.Trim(nonIdentifier.ReplaceAllString(name, "_"), "_")
+}
+
+const header = "// Code generated by ianagen from the IANA registry CSVs; DO NOT EDIT.\n\npackage dactyloscopy\n\n"
+
+const (
+	ciphersuiteDoc = "// ianaCiphersuites is the TLS Cipher Suites registry"
+	groupDoc       = "// ianaSupportedGroups is the TLS Supported Groups registry"
+	schemeDoc      = "// ianaSignatureSchemes is the TLS SignatureScheme registry"
+	deprecatedDoc  = "\n// Deprecated is set where the registry marks the value as discouraged (D)\n"
+)
+
+func genUint16Map(varName, doc string, entries []entry) []byte {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString(doc + "." + deprecatedDoc)
+	fmt.Fprintf(&b, "var %s = map[uint16]IanaEntry{\n", varName)
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\t0x%04X: {Name: %q, DTLSOK: %t, Recommended: %t, Deprecated: %t},\n", e.value, e.name, e.dtlsOK, e.recommended, e.deprecated)
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func genExtensions(entries []entry) []byte {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("// IanaExtension is a TLS extension type, which prints as its registry name\n")
+	b.WriteString("type IanaExtension uint16\n\nconst (\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\t%s IanaExtension = %d\n", e.name, e.value)
+	}
+	b.WriteString("\n\t// PrivateUse is the first value reserved for private use, which earlier\n")
+	b.WriteString("\t// releases exported\n")
+	b.WriteString("\tPrivateUse IanaExtension = 65280\n")
+	b.WriteString(")\n\n")
+	b.WriteString("// ianaExtensions is the TLS ExtensionType Values registry, together with a\n")
+	b.WriteString("// handful of widely deployed but unregistered extensions." + deprecatedDoc)
+	b.WriteString("var ianaExtensions = map[uint16]IanaEntry{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\t0x%04X: {Name: %q, Recommended: %t, Deprecated: %t},\n", e.value, e.name, e.recommended, e.deprecated)
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func genExtensionStringer() []byte {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString(`import "strconv"
+
+func (i IanaExtension) String() string {
+	if entry, ok := ianaExtensions[uint16(i)]; ok {
+		return entry.Name
+	}
+	return "IanaExtension(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+`)
+	return b.Bytes()
+}
+
+func genALPN(entries []entry) []byte {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("// ianaALPN is the TLS Application-Layer Protocol Negotiation (ALPN) Protocol\n")
+	b.WriteString("// IDs registry, keyed by the identification sequence sent on the wire\n")
+	b.WriteString("var ianaALPN = map[string]IanaEntry{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\t%q: {Name: %q},\n", e.key, e.name)
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValues(t *testing.T) {
+	v, ok := parseSuiteValue("0xC0,0x2B")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0xc02b), v)
+
+	_, ok = parseSuiteValue("0x00,0x1C-1D")
+	assert.False(t, ok)
+
+	_, ok = parseDecimalValue("65282-65535")
+	assert.False(t, ok)
+
+	v, ok = parseHexValue("0x0804")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x0804), v)
+
+	assert.Equal(t, "connection_id_deprecated", identifier("connection_id (deprecated)"))
+}
+
+func TestGeneratedKeysHex(t *testing.T) {
+	entries := []entry{{value: 0x11ec, name: "X25519MLKEM768", dtlsOK: true}}
+	assert.Contains(t, string(genUint16Map("ianaSupportedGroups", groupDoc, entries)),
+		"\t0x11EC: {Name: \"X25519MLKEM768\", DTLSOK: true, Recommended: false, Deprecated: false},\n")
+	assert.Contains(t, string(genExtensions([]entry{{value: 0xff01, name: "renegotiation_info", recommended: true}})),
+		"\t0xFF01: {Name: \"renegotiation_info\", Recommended: true, Deprecated: false},\n")
+}
+
+// TestGeneratedTablesUpToDate makes sure nobody has hand edited the generated
+// tables, or updated the CSVs without running go generate
+func TestGeneratedTablesUpToDate(t *testing.T) {
+	out := t.TempDir()
+	require.NoError(t, run("registries", out))
+
+	files, err := os.ReadDir(out)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		want, err := os.ReadFile(filepath.Join(out, file.Name()))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join("..", "..", file.Name()))
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "%s is out of date, run go generate", file.Name())
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.csv" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "Value,Description\n"+r.URL.Path+"\n")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, fetch(server.Client(), map[string]string{
+		"a.csv": server.URL + "/a",
+		"b.csv": server.URL + "/b",
+	}, dir))
+	got, err := os.ReadFile(filepath.Join(dir, "b.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "Value,Description\n/b\n", string(got))
+
+	// Nothing is written if any download fails
+	err = fetch(server.Client(), map[string]string{
+		"c.csv": server.URL + "/c",
+		"d.csv": server.URL + "/missing.csv",
+	}, dir)
+	assert.ErrorContains(t, err, "404")
+	assert.NoFileExists(t, filepath.Join(dir, "c.csv"))
+}
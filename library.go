@@ -0,0 +1,295 @@
+package dactyloscopy
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// TLSLibrary is the name of a TLS implementation, as guessed by IdentifyLibrary
+type TLSLibrary string
+
+const (
+	LibraryBoringSSL  TLSLibrary = "BoringSSL"
+	LibraryNSS        TLSLibrary = "NSS"
+	LibraryOpenSSL10  TLSLibrary = "OpenSSL 1.0.x"
+	LibraryOpenSSL111 TLSLibrary = "OpenSSL 1.1.1"
+	LibraryOpenSSL3   TLSLibrary = "OpenSSL 3.x"
+	LibraryGo         TLSLibrary = "Go crypto/tls"
+	LibraryRustls     TLSLibrary = "rustls"
+	LibrarySChannel   TLSLibrary = "SChannel"
+	LibraryApple      TLSLibrary = "Secure Transport / Network.framework"
+	LibraryJSSE       TLSLibrary = "Java JSSE"
+	LibraryPython     TLSLibrary = "Python ssl"
+)
+
+// LibraryGuess is a candidate TLS library along with the traits of the client
+// hello which support (or count against) it.  Score is between 0 and 1
+type LibraryGuess struct {
+	Library  TLSLibrary `json:"library"`
+	Score    float64    `json:"score"`
+	Evidence []string   `json:"evidence"`
+}
+
+// IdentifyLibrary infers the likely TLS library behind a client hello from
+// structural traits alone (cipher ordering, GREASE, padding, extension
+// conventions and so on), without reference to a fingerprint database.  The
+// result is ranked best guess first, libraries with no supporting evidence are
+// omitted.  These are heuristics, applications can and do change the defaults
+// of their TLS library, so treat the result as a hint rather than an answer.
+func IdentifyLibrary(f *Fingerprint) []LibraryGuess {
+	h := newHelloTraits(f)
+
+	var guesses []LibraryGuess
+	for _, profile := range libraryProfiles {
+		var (
+			possible float64
+			matched  float64
+			evidence []string
+		)
+		for _, t := range profile.traits {
+			if t.weight > 0 {
+				possible += t.weight
+			}
+			if !t.match(h) {
+				continue
+			}
+			matched += t.weight
+			if t.weight > 0 {
+				evidence = append(evidence, t.description)
+			} else {
+				evidence = append(evidence, "against: "+t.description)
+			}
+		}
+		if matched <= 0 || possible == 0 {
+			continue
+		}
+		guesses = append(guesses, LibraryGuess{
+			Library:  profile.library,
+			Score:    matched / possible,
+			Evidence: evidence,
+		})
+	}
+
+	sort.SliceStable(guesses, func(i, j int) bool {
+		return guesses[i].Score > guesses[j].Score
+	})
+	return guesses
+}
+
+// helloTraits holds the client hello in a form which is convenient to test
+// traits against
+type helloTraits struct {
+	f          *Fingerprint
+	extensions []uint16 // without GREASE
+	greaseExt  bool
+	greaseAny  bool
+}
+
+func newHelloTraits(f *Fingerprint) *helloTraits {
+	h := &helloTraits{f: f, extensions: withoutGrease(f.Extensions)}
+	h.greaseExt = len(h.extensions) != len(f.Extensions)
+	h.greaseAny = f.Grease || h.greaseExt || len(withoutGrease(f.ECurves)) != len(f.ECurves)
+	return h
+}
+
+func (h *helloTraits) ext(ext uint16) bool {
+	return slices.Contains(h.extensions, ext)
+}
+
+func (h *helloTraits) cipher(suite uint16) bool {
+	return slices.Contains(h.f.Ciphersuite, suite)
+}
+
+func (h *helloTraits) group(group uint16) bool {
+	return slices.Contains(h.f.ECurves, group)
+}
+
+func (h *helloTraits) sigalg(scheme uint16) bool {
+	return slices.Contains(h.f.SigAlg, scheme)
+}
+
+func (h *helloTraits) ciphersStart(suites ...uint16) bool {
+	return hasPrefix(withoutGrease(h.f.Ciphersuite), suites)
+}
+
+func (h *helloTraits) sigalgsStart(schemes ...uint16) bool {
+	return hasPrefix(h.f.SigAlg, schemes)
+}
+
+func (h *helloTraits) lastExt() uint16 {
+	if len(h.extensions) == 0 {
+		return 0
+	}
+	return h.extensions[len(h.extensions)-1]
+}
+
+func (h *helloTraits) ffdhe() bool {
+	return h.group(0x0100) || h.group(0x0101) || h.group(0x0102)
+}
+
+// tls13SuitesLast is the Go crypto/tls habit of listing the TLS 1.3 suites
+// after all of the TLS 1.2 ones
+func (h *helloTraits) tls13SuitesLast() bool {
+	first13 := slices.Index(h.f.Ciphersuite, 0x1301)
+	first12 := slices.IndexFunc(h.f.Ciphersuite, func(s uint16) bool { return s>>8 == 0xc0 || s>>8 == 0xcc })
+	return first13 > 0 && first12 >= 0 && first13 > first12
+}
+
+func (h *helloTraits) pointFormats(formats ...uint8) bool {
+	return slices.Equal(h.f.EcPointFmt, formats)
+}
+
+func hasPrefix(values, prefix []uint16) bool {
+	return len(values) >= len(prefix) && slices.Equal(values[:len(prefix)], prefix)
+}
+
+type libraryTrait struct {
+	weight      float64
+	description string
+	match       func(h *helloTraits) bool
+}
+
+type libraryProfile struct {
+	library TLSLibrary
+	traits  []libraryTrait
+}
+
+func hasExtTrait(weight float64, ext uint16) libraryTrait {
+	return libraryTrait{
+		weight:      weight,
+		description: fmt.Sprintf("sends %s extension", extensionName(ext)),
+		match:       func(h *helloTraits) bool { return h.ext(ext) },
+	}
+}
+
+var (
+	noGrease = libraryTrait{
+		weight:      -3,
+		description: "uses GREASE",
+		match:       func(h *helloTraits) bool { return h.greaseAny },
+	}
+	usesGrease = libraryTrait{
+		weight:      3,
+		description: "uses GREASE in ciphersuites and extensions",
+		match:       func(h *helloTraits) bool { return h.f.Grease && h.greaseExt },
+	}
+	lacksGrease = libraryTrait{
+		weight:      -2,
+		description: "does not use GREASE",
+		match:       func(h *helloTraits) bool { return !h.greaseAny },
+	}
+	openSSLCipherOrder = libraryTrait{
+		weight:      2,
+		description: "TLS 1.3 ciphersuites ordered AES-256, ChaCha20, AES-128",
+		match:       func(h *helloTraits) bool { return h.ciphersStart(0x1302, 0x1303, 0x1301) },
+	}
+	openSSLPointFormats = libraryTrait{
+		weight:      1,
+		description: "offers all three EC point formats",
+		match:       func(h *helloTraits) bool { return h.pointFormats(0, 1, 2) },
+	}
+	supportedVersions = libraryTrait{
+		weight:      2,
+		description: "sends supported_versions (TLS 1.3 capable)",
+		match:       func(h *helloTraits) bool { return h.ext(ExtSupportedVersions) },
+	}
+)
+
+var libraryProfiles = []libraryProfile{
+	{LibraryBoringSSL, []libraryTrait{
+		usesGrease,
+		hasExtTrait(2, 0x001b), // compress_certificate
+		{2, "sends ALPS (application_settings)", func(h *helloTraits) bool { return h.ext(0x4469) || h.ext(0x44cd) }},
+		{1, "TLS 1.3 ciphersuites first in AES-128, AES-256, ChaCha20 order", func(h *helloTraits) bool {
+			return h.ciphersStart(0x1301, 0x1302, 0x1303, 0xc02b, 0xc02f)
+		}},
+		{1, "pads the client hello", func(h *helloTraits) bool { return h.f.Padding }},
+		lacksGrease,
+	}},
+	{LibraryNSS, []libraryTrait{
+		{2, "TLS 1.3 ciphersuites ordered AES-128, ChaCha20, AES-256", func(h *helloTraits) bool { return h.ciphersStart(0x1301, 0x1303, 0x1302) }},
+		hasExtTrait(2, 0x001c), // record_size_limit
+		hasExtTrait(2, 0x0022), // delegated_credential
+		{1, "offers ffdhe groups", (*helloTraits).ffdhe},
+		{1, "offers ecdsa_sha1 signatures", func(h *helloTraits) bool { return h.sigalg(0x0203) }},
+		noGrease,
+	}},
+	{LibraryOpenSSL10, []libraryTrait{
+		{3, "no supported_versions extension (TLS 1.2 or lower only)", func(h *helloTraits) bool { return !h.ext(ExtSupportedVersions) }},
+		hasExtTrait(1, 0x000f), // heartbeat
+		hasExtTrait(2, 0x0016), // encrypt_then_mac
+		hasExtTrait(1, 0x0023), // session_ticket
+		openSSLPointFormats,
+		noGrease,
+	}},
+	{LibraryOpenSSL111, []libraryTrait{
+		openSSLCipherOrder,
+		supportedVersions,
+		hasExtTrait(2, 0x0016), // encrypt_then_mac
+		openSSLPointFormats,
+		{1, "no ffdhe groups offered", func(h *helloTraits) bool { return !h.ffdhe() }},
+		{-2, "post_handshake_auth enabled, which OpenSSL doesn't do by default", func(h *helloTraits) bool { return h.ext(0x0031) }},
+		noGrease,
+	}},
+	{LibraryOpenSSL3, []libraryTrait{
+		openSSLCipherOrder,
+		supportedVersions,
+		hasExtTrait(2, 0x0016), // encrypt_then_mac
+		openSSLPointFormats,
+		{2, "offers ffdhe groups", (*helloTraits).ffdhe},
+		{-2, "post_handshake_auth enabled, which OpenSSL doesn't do by default", func(h *helloTraits) bool { return h.ext(0x0031) }},
+		noGrease,
+	}},
+	{LibraryPython, []libraryTrait{
+		openSSLCipherOrder,
+		hasExtTrait(2, 0x0016), // encrypt_then_mac
+		openSSLPointFormats,
+		{3, "post_handshake_auth enabled, as Python's default client context does", func(h *helloTraits) bool { return h.ext(0x0031) }},
+		noGrease,
+	}},
+	{LibraryGo, []libraryTrait{
+		{3, "TLS 1.3 ciphersuites listed after TLS 1.2 ones", (*helloTraits).tls13SuitesLast},
+		{2, "ciphersuites start with ECDHE AES-GCM in Go's default order", func(h *helloTraits) bool {
+			return h.ciphersStart(0xc02b, 0xc02f, 0xc02c, 0xc030, 0xcca9, 0xcca8)
+		}},
+		{1, "sends status_request and signed_certificate_timestamp", func(h *helloTraits) bool { return h.ext(0x0005) && h.ext(0x0012) }},
+		{1, "does not pad the client hello", func(h *helloTraits) bool { return !h.f.Padding }},
+		noGrease,
+	}},
+	{LibraryRustls, []libraryTrait{
+		{3, "signals renegotiation with the SCSV rather than the extension", func(h *helloTraits) bool { return h.cipher(0x00ff) && !h.ext(0xff01) }},
+		{2, "ciphersuites in rustls' default order", func(h *helloTraits) bool {
+			return h.ciphersStart(0x1302, 0x1301, 0x1303, 0xc02c, 0xc02b, 0xcca9)
+		}},
+		{2, "signature algorithms in rustls' default order", func(h *helloTraits) bool { return h.sigalgsStart(0x0503, 0x0403, 0x0807) }},
+		noGrease,
+	}},
+	{LibrarySChannel, []libraryTrait{
+		{3, "ECDHE AES-GCM suites ordered AES-256 first, ECDSA before RSA", func(h *helloTraits) bool {
+			return h.ciphersStart(0xc02c, 0xc02b, 0xc030, 0xc02f) || h.ciphersStart(0x1302, 0x1301, 0xc02c, 0xc02b, 0xc030, 0xc02f)
+		}},
+		{2, "offers DHE AES-GCM suites", func(h *helloTraits) bool { return h.cipher(0x009f) && h.cipher(0x009e) }},
+		{2, "renegotiation_info is the last extension", func(h *helloTraits) bool { return h.lastExt() == 0xff01 }},
+		{1, "groups are exactly x25519, secp256r1, secp384r1", func(h *helloTraits) bool {
+			return slices.Equal(withoutGrease(h.f.ECurves), []uint16{0x001d, 0x0017, 0x0018})
+		}},
+		noGrease,
+	}},
+	{LibraryApple, []libraryTrait{
+		usesGrease,
+		{2, "still offers 3DES suites", func(h *helloTraits) bool { return h.cipher(0xc008) || h.cipher(0xc012) || h.cipher(0x000a) }},
+		{1, "TLS 1.2 suites ordered AES-256 GCM, AES-128 GCM, ChaCha20", func(h *helloTraits) bool {
+			return h.ciphersStart(0x1301, 0x1302, 0x1303, 0xc02c, 0xc02b, 0xcca9)
+		}},
+		hasExtTrait(1, 0x001b), // compress_certificate
+		{-2, "sends ALPS, which is BoringSSL only", func(h *helloTraits) bool { return h.ext(0x4469) || h.ext(0x44cd) }},
+	}},
+	{LibraryJSSE, []libraryTrait{
+		hasExtTrait(3, 0x0011), // status_request_v2
+		hasExtTrait(3, 0x0032), // signature_algorithms_cert
+		{1, "offers ffdhe groups", (*helloTraits).ffdhe},
+		{1, "sends the renegotiation SCSV", func(h *helloTraits) bool { return h.cipher(0x00ff) }},
+		noGrease,
+	}},
+}
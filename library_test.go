@@ -0,0 +1,63 @@
+package dactyloscopy_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifyLibrary(t *testing.T) {
+	goFP, err := dactyloscopy.ProcessClientHello(goClientHello(t, &tls.Config{ServerName: "example.com"}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		fp           *dactyloscopy.Fingerprint
+		want         dactyloscopy.TLSLibrary
+		wantEvidence string
+	}{
+		{
+			name:         "crypto/tls",
+			fp:           goFP,
+			want:         dactyloscopy.LibraryGo,
+			wantEvidence: "TLS 1.3 ciphersuites listed after TLS 1.2 ones",
+		},
+		{
+			name: "BoringSSL",
+			fp: &dactyloscopy.Fingerprint{
+				Grease:      true,
+				Ciphersuite: []uint16{0x1301, 0x1302, 0x1303, 0xc02b, 0xc02f, 0xc02c, 0xc030, 0xcca9, 0xcca8, 0xc013, 0xc014, 0x009c, 0x009d, 0x002f, 0x0035},
+				Extensions:  []uint16{0x8a8a, 0x0012, 0x0033, 0x002b, 0x000d, 0x001b, 0x44cd, 0x0000, 0x0017, 0x0010, 0x000a, 0x002d, 0xff01, 0x0005, 0x000b, 0x0023, 0xfe0d, 0x3a3a},
+				ECurves:     []uint16{0x8a8a, 0x11ec, 0x001d, 0x0017, 0x0018},
+				EcPointFmt:  []uint8{0},
+				Padding:     true,
+			},
+			want:         dactyloscopy.LibraryBoringSSL,
+			wantEvidence: "sends ALPS (application_settings)",
+		},
+		{
+			name: "JSSE",
+			fp: &dactyloscopy.Fingerprint{
+				Ciphersuite: []uint16{0x1302, 0x1301, 0x1303, 0xc02c, 0xc02b, 0xcca9, 0xc030, 0xcca8, 0xc02f, 0x009f, 0x00ff},
+				Extensions:  []uint16{0x0005, 0x000a, 0x000b, 0x0011, 0x0023, 0x000d, 0x0032, 0x0010, 0x0017, 0x002b, 0x002d, 0x0033},
+				ECurves:     []uint16{0x001d, 0x0017, 0x0018, 0x0019, 0x001e, 0x0100, 0x0101},
+				EcPointFmt:  []uint8{0},
+			},
+			want: dactyloscopy.LibraryJSSE,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guesses := dactyloscopy.IdentifyLibrary(tt.fp)
+			require.NotEmpty(t, guesses)
+			assert.Equal(t, tt.want, guesses[0].Library)
+			if tt.wantEvidence != "" {
+				assert.Contains(t, guesses[0].Evidence, tt.wantEvidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package dactyloscopy
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseJA3String reconstructs a partial fingerprint from a full (unhashed) JA3
+// string, e.g. "771,4865-4866-4867,0-23-65281,29-23-24,0", as found in threat
+// intel feeds.  The version, ciphersuites, extensions, groups and point formats
+// are populated, and everything else is listed in Unknown.  JA3 and JA3N are
+// calculated, but JA4 is not as a JA3 string carries no ALPN.
+func ParseJA3String(ja3 string) (*Fingerprint, error) {
+	fields := strings.Split(strings.TrimSpace(ja3), ",")
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("JA3 string has %d fields, expected 5", len(fields))
+	}
+
+	f := &Fingerprint{
+		MessageType: HandshakeType,
+		Unknown: []string{
+			ComponentSignatureAlgorithms,
+			ComponentSupportedVersions,
+			ComponentALPN,
+			ComponentKeyShares,
+			ComponentCompression,
+			ComponentSNI,
+		},
+	}
+
+	version, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JA3 version %q: %w", fields[0], err)
+	}
+	f.TLSVersion = uint16(version)
+
+	suites, err := parseList[uint16](fields[1], "-", 10)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JA3 ciphersuites: %w", err)
+	}
+	// Match the ClientHello parser, which drops GREASE suites and notes them
+	for _, suite := range suites {
+		if isGrease(suite) {
+			f.Grease = true
+			continue
+		}
+		f.Ciphersuite = append(f.Ciphersuite, suite)
+	}
+
+	if f.Extensions, err = parseList[uint16](fields[2], "-", 10); err != nil {
+		return nil, fmt.Errorf("parsing JA3 extensions: %w", err)
+	}
+	if f.ECurves, err = parseList[uint16](fields[3], "-", 10); err != nil {
+		return nil, fmt.Errorf("parsing JA3 groups: %w", err)
+	}
+	if f.EcPointFmt, err = parseList[uint8](fields[4], "-", 10); err != nil {
+		return nil, fmt.Errorf("parsing JA3 point formats: %w", err)
+	}
+
+	if err := f.generateJA3(); err != nil {
+		return nil, fmt.Errorf("error generating JA3: %w", err)
+	}
+	if err := f.generateJA3N(); err != nil {
+		return nil, fmt.Errorf("error generating JA3N: %w", err)
+	}
+	return f, nil
+}
+
+// ja4Versions maps the version field of a JA4 string to a TLS version
+var ja4Versions = map[string]uint16{
+	"13": VersionTLS13,
+	"12": VersionTLS12,
+	"11": VersionTLS11,
+	"10": VersionTLS10,
+	"s3": 0x0300,
+	"d1": 0xfeff,
+	"d2": 0xfefd,
+	"d3": 0xfefc,
+}
+
+// ja4ALPNHints maps the first and last characters of the first ALPN protocol,
+// as carried by JA4, to the protocols they almost always stand for
+var ja4ALPNHints = map[string]string{
+	"h1": "http/1.1",
+	"h2": "h2",
+	"h3": "h3",
+}
+
+// ParseJA4Raw reconstructs a partial fingerprint from a JA4_r string, e.g.
+// "t13d1516h2_002f,0035,..._0005,000a,..._0403,0804,...".  JA4_ro strings,
+// which keep the original order, are also accepted.
+//
+// JA4 only carries the ciphersuites, extensions and signature algorithms, and
+// JA4_r sorts the first two, so the groups, point formats, key shares,
+// compression, supported versions and (for JA4_r) ordering are listed in
+// Unknown.  ALPNProtocols holds a best guess at the first protocol from the two
+// characters JA4 keeps, which is why ALPN is also listed in Unknown.  TLS 1.3
+// is recorded as a TLSVersion of 1.2, as it would be in the ClientHello.  No
+// hashes are calculated as there isn't enough information for any of them.
+func ParseJA4Raw(ja4r string) (*Fingerprint, error) {
+	sections := strings.Split(strings.TrimSpace(ja4r), "_")
+	if len(sections) != 3 && len(sections) != 4 {
+		return nil, fmt.Errorf("JA4_r string has %d sections, expected 3 or 4", len(sections))
+	}
+	prefix := sections[0]
+	if len(prefix) != 10 {
+		return nil, fmt.Errorf("JA4 prefix %q should be 10 characters", prefix)
+	}
+
+	f := &Fingerprint{
+		MessageType: HandshakeType,
+		Unknown: []string{
+			ComponentSupportedGroups,
+			ComponentECPointFormats,
+			ComponentSupportedVersions,
+			ComponentKeyShares,
+			ComponentCompression,
+		},
+	}
+
+	switch prefix[0] {
+	case 't', 'q', 'd':
+	default:
+		return nil, fmt.Errorf("unknown JA4 protocol %q", prefix[0])
+	}
+
+	if version, ok := ja4Versions[prefix[1:3]]; ok {
+		// (D)TLS 1.3 hellos carry the 1.2 version, 1.3 is in supported_versions
+		switch version {
+		case VersionTLS13:
+			version = VersionTLS12
+		case 0xfefc:
+			version = 0xfefd
+		}
+		f.TLSVersion = version
+	} else {
+		f.Unknown = append(f.Unknown, ComponentTLSVersion)
+	}
+
+	var sni bool
+	switch prefix[3] {
+	case 'd':
+		sni = true
+		// The server name itself isn't in JA4
+		f.Unknown = append(f.Unknown, ComponentSNI)
+	case 'i':
+	default:
+		return nil, fmt.Errorf("unknown JA4 SNI indicator %q", prefix[3])
+	}
+
+	suiteCount, err := strconv.Atoi(prefix[4:6])
+	if err != nil {
+		return nil, fmt.Errorf("parsing JA4 ciphersuite count %q: %w", prefix[4:6], err)
+	}
+	extCount, err := strconv.Atoi(prefix[6:8])
+	if err != nil {
+		return nil, fmt.Errorf("parsing JA4 extension count %q: %w", prefix[6:8], err)
+	}
+
+	alpn := prefix[8:10]
+	if alpn != "00" {
+		f.Unknown = append(f.Unknown, ComponentALPN)
+		if hint, ok := ja4ALPNHints[alpn]; ok {
+			f.ALPNProtocols = []string{hint}
+		}
+	}
+
+	if f.Ciphersuite, err = parseList[uint16](sections[1], ",", 16); err != nil {
+		return nil, fmt.Errorf("parsing JA4 ciphersuites: %w", err)
+	}
+	if f.Extensions, err = parseList[uint16](sections[2], ",", 16); err != nil {
+		return nil, fmt.Errorf("parsing JA4 extensions: %w", err)
+	}
+	if len(sections) == 4 {
+		if f.SigAlg, err = parseList[uint16](sections[3], ",", 16); err != nil {
+			return nil, fmt.Errorf("parsing JA4 signature algorithms: %w", err)
+		}
+	} else {
+		// Without the fourth section there's no telling whether the client
+		// sent signature algorithms
+		f.Unknown = append(f.Unknown, ComponentSignatureAlgorithms)
+	}
+
+	// JA4_r sorts the lists and leaves SNI and ALPN out of the extensions,
+	// JA4_ro keeps the original order and everything in
+	original := !slices.IsSorted(f.Ciphersuite) || !slices.IsSorted(f.Extensions) ||
+		slices.Contains(f.Extensions, ExtServerName) || slices.Contains(f.Extensions, ExtALPN)
+	if !original {
+		if sni {
+			f.Extensions = append(f.Extensions, ExtServerName)
+		}
+		if alpn != "00" {
+			f.Extensions = append(f.Extensions, ExtALPN)
+		}
+		f.Extensions = sortNumericAsc(f.Extensions)
+		f.Unknown = append(f.Unknown, ComponentCiphersuiteOrder, ComponentExtensionOrder)
+	}
+
+	// The counts are capped at 99
+	if len(f.Ciphersuite) != suiteCount && suiteCount < 99 {
+		return nil, fmt.Errorf("JA4 ciphersuite count is %d, but %d are listed", suiteCount, len(f.Ciphersuite))
+	}
+	if len(f.Extensions) != extCount && extCount < 99 {
+		return nil, fmt.Errorf("JA4 extension count is %d, but %d are listed", extCount, len(f.Extensions))
+	}
+	return f, nil
+}
+
+// parseList parses a separated list of numbers in the given base, an empty
+// string being an empty list
+func parseList[T uint8 | uint16](list, sep string, base int) ([]T, error) {
+	if list == "" {
+		return nil, nil
+	}
+	var (
+		zero T
+		out  []T
+	)
+	bits := 16
+	if _, ok := any(zero).(uint8); ok {
+		bits = 8
+	}
+	for _, item := range strings.Split(list, sep) {
+		v, err := strconv.ParseUint(strings.TrimSpace(item), base, bits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", item, err)
+		}
+		out = append(out, T(v))
+	}
+	return out, nil
+}
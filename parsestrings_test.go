@@ -0,0 +1,202 @@
+package dactyloscopy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+)
+
+func TestParseJA3String(t *testing.T) {
+	tests := []struct {
+		name       string
+		ja3        string
+		wantErr    bool
+		wantJA3    string
+		wantSuites []uint16
+		wantCurves []uint16
+		wantGrease bool
+	}{
+		{
+			name: "Chrome",
+			ja3:  "771,2570-4865-4866-4867-49195,2570-0-23-65281-10-11-35-16-5-13-18-51-45-43-27-17513-21,2570-29-23-24,0",
+			// The hash is of the string without the GREASE cipher, as the
+			// parser strips it
+			wantJA3:    "4b5a2da97b80c0a509d9e2d69b13a97a",
+			wantSuites: []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+			wantCurves: []uint16{0x0a0a, 0x001d, 0x0017, 0x0018},
+			wantGrease: true,
+		},
+		{
+			name:       "no curves",
+			ja3:        "771,47-53,0-23,,",
+			wantJA3:    "156102986843f89c5efc7acae68efe6d",
+			wantSuites: []uint16{0x002f, 0x0035},
+		},
+		{name: "empty", ja3: "", wantErr: true},
+		{name: "too few fields", ja3: "771,4865,0,29", wantErr: true},
+		{name: "too many fields", ja3: "771,4865,0,29,0,1", wantErr: true},
+		{name: "bad version", ja3: "x,4865,0,29,0", wantErr: true},
+		{name: "bad ciphersuite", ja3: "771,4865-abc,0,29,0", wantErr: true},
+		{name: "point format out of range", ja3: "771,4865,0,29,256", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := dactyloscopy.ParseJA3String(tt.ja3)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, dactyloscopy.VersionTLS12, f.TLSVersion)
+			assert.Equal(t, tt.wantJA3, f.JA3)
+			assert.NotEmpty(t, f.JA3N)
+			assert.Empty(t, f.JA4)
+			assert.Equal(t, tt.wantSuites, f.Ciphersuite)
+			assert.Equal(t, tt.wantCurves, f.ECurves)
+			assert.Equal(t, tt.wantGrease, f.Grease)
+
+			assert.True(t, f.Known(dactyloscopy.ComponentCiphersuites))
+			assert.False(t, f.Known(dactyloscopy.ComponentALPN))
+			assert.False(t, f.Known(dactyloscopy.ComponentSignatureAlgorithms))
+		})
+	}
+}
+
+func TestParseJA3StringDiff(t *testing.T) {
+	f, err := dactyloscopy.ParseJA3String("771,4865-4866,0-10-16-43,29,0")
+	require.NoError(t, err)
+	observed := &dactyloscopy.Fingerprint{
+		TLSVersion:     dactyloscopy.VersionTLS12,
+		Ciphersuite:    []uint16{0x1301, 0x1302},
+		Extensions:     []uint16{0, 10, 16, 43},
+		ECurves:        []uint16{0x001d},
+		EcPointFmt:     []uint8{0},
+		SigAlg:         []uint16{0x0403},
+		ALPNProtocols:  []string{"h2"},
+		KeyShareGroups: []uint16{0x001d},
+	}
+
+	// Components the JA3 string doesn't carry are marked unknown rather than
+	// reported as removed
+	d := dactyloscopy.Diff(observed, f)
+	assert.True(t, d.Identical)
+	for _, c := range d.Components {
+		switch c.Component {
+		case dactyloscopy.ComponentALPN, dactyloscopy.ComponentSignatureAlgorithms, dactyloscopy.ComponentKeyShares:
+			assert.True(t, c.Unknown, c.Component)
+		case dactyloscopy.ComponentCiphersuites:
+			assert.False(t, c.Unknown, c.Component)
+		}
+	}
+}
+
+func TestParseJA4Raw(t *testing.T) {
+	tests := []struct {
+		name       string
+		ja4r       string
+		wantErr    bool
+		wantSuites []uint16
+		wantExts   []uint16
+		wantSigAlg []uint16
+		wantALPN   []string
+		known      []string
+		unknown    []string
+	}{
+		{
+			name: "sorted with SNI",
+			ja4r: "t13d0204h2_1302,c02b_000a,002b_0403,0804",
+			// SNI and ALPN are left out of the sorted extensions, and put back
+			wantSuites: []uint16{0x1302, 0xc02b},
+			wantExts:   []uint16{0x0000, 0x000a, 0x0010, 0x002b},
+			wantSigAlg: []uint16{0x0403, 0x0804},
+			wantALPN:   []string{"h2"},
+			known:      []string{dactyloscopy.ComponentSignatureAlgorithms},
+			unknown: []string{
+				dactyloscopy.ComponentSupportedGroups,
+				dactyloscopy.ComponentECPointFormats,
+				dactyloscopy.ComponentALPN,
+				dactyloscopy.ComponentSNI,
+				dactyloscopy.ComponentCiphersuiteOrder,
+				dactyloscopy.ComponentExtensionOrder,
+			},
+		},
+		{
+			name:       "original order",
+			ja4r:       "t12i0302h1_c02b,1301,c02f_0010,000a_0403",
+			wantSuites: []uint16{0xc02b, 0x1301, 0xc02f},
+			wantExts:   []uint16{0x0010, 0x000a},
+			wantSigAlg: []uint16{0x0403},
+			wantALPN:   []string{"http/1.1"},
+			known: []string{
+				dactyloscopy.ComponentCiphersuiteOrder,
+				dactyloscopy.ComponentExtensionOrder,
+				dactyloscopy.ComponentSNI,
+			},
+		},
+		{
+			name:       "empty signature algorithms",
+			ja4r:       "t13i010100_1301_002b_",
+			wantSuites: []uint16{0x1301},
+			wantExts:   []uint16{0x002b},
+			known:      []string{dactyloscopy.ComponentSignatureAlgorithms, dactyloscopy.ComponentALPN},
+		},
+		{
+			name:       "no signature algorithms section",
+			ja4r:       "t13i010100_1301_002b",
+			wantSuites: []uint16{0x1301},
+			wantExts:   []uint16{0x002b},
+			unknown:    []string{dactyloscopy.ComponentSignatureAlgorithms},
+		},
+		{name: "empty", ja4r: "", wantErr: true},
+		{name: "prefix only", ja4r: "t13d1516h2", wantErr: true},
+		{name: "short prefix", ja4r: "t13d15h2_002f_0005", wantErr: true},
+		{name: "bad protocol", ja4r: "x13d0101h2_002f_0005", wantErr: true},
+		{name: "bad SNI indicator", ja4r: "t13x0101h2_002f_0005", wantErr: true},
+		{name: "wrong ciphersuite count", ja4r: "t13i020100_002f_0005", wantErr: true},
+		{name: "bad extension", ja4r: "t13i010100_002f_zzzz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := dactyloscopy.ParseJA4Raw(tt.ja4r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, dactyloscopy.VersionTLS12, f.TLSVersion)
+			assert.Equal(t, tt.wantSuites, f.Ciphersuite)
+			assert.Equal(t, tt.wantExts, f.Extensions)
+			assert.Equal(t, tt.wantSigAlg, f.SigAlg)
+			assert.Equal(t, tt.wantALPN, f.ALPNProtocols)
+			for _, c := range tt.known {
+				assert.True(t, f.Known(c), c)
+			}
+			for _, c := range tt.unknown {
+				assert.False(t, f.Known(c), c)
+			}
+		})
+	}
+}
+
+func TestSimilarityPartial(t *testing.T) {
+	f, err := dactyloscopy.ParseJA3String("771,4865-4866,2570-0-10-43,2570-29,0")
+	require.NoError(t, err)
+	observed := &dactyloscopy.Fingerprint{
+		TLSVersion:    dactyloscopy.VersionTLS12,
+		Ciphersuite:   []uint16{0x1301, 0x1302},
+		Extensions:    []uint16{0x3a3a, 0, 10, 43},
+		ECurves:       []uint16{0x3a3a, 0x001d},
+		EcPointFmt:    []uint8{0},
+		SigAlg:        []uint16{0x0403},
+		ALPNProtocols: []string{"h2"},
+	}
+
+	// Everything the JA3 string carries matches, GREASE values aside, and
+	// the rest is ignored
+	assert.InDelta(t, 1.0, dactyloscopy.Similarity(observed, f), 0.0001)
+}
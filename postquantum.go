@@ -0,0 +1,147 @@
+package dactyloscopy
+
+import "encoding/json"
+
+// GroupClass describes the kind of key exchange a supported group provides
+type GroupClass uint8
+
+const (
+	// GroupUnknown is used for GREASE and any group we have no knowledge of
+	GroupUnknown GroupClass = iota
+	// GroupClassical is a traditional (EC)DHE group, e.g. x25519 or ffdhe2048
+	GroupClassical
+	// GroupHybridPQ combines a classical group with a post-quantum KEM
+	GroupHybridPQ
+	// GroupPurePQ is a post-quantum KEM used on its own
+	GroupPurePQ
+)
+
+func (g GroupClass) String() string {
+	switch g {
+	case GroupClassical:
+		return "classical"
+	case GroupHybridPQ:
+		return "hybrid_pq"
+	case GroupPurePQ:
+		return "pure_pq"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON renders the class by name rather than number
+func (g GroupClass) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.String())
+}
+
+// pqGroups lists every group which is known to carry a post-quantum component.
+// This includes a couple of pre-standard codepoints (0xFE30/0xFE31) which were
+// used by early Kyber experiments and still turn up in the wild.
+var pqGroups = map[uint16]GroupClass{
+	0x0200: GroupPurePQ,   // MLKEM512
+	0x0201: GroupPurePQ,   // MLKEM768
+	0x0202: GroupPurePQ,   // MLKEM1024
+	0x11EB: GroupHybridPQ, // SecP256r1MLKEM768
+	0x11EC: GroupHybridPQ, // X25519MLKEM768
+	0x11ED: GroupHybridPQ, // SecP384r1MLKEM1024
+	0x11EE: GroupHybridPQ, // curveSM2MLKEM768
+	0x6399: GroupHybridPQ, // X25519Kyber768Draft00
+	0x639A: GroupHybridPQ, // SecP256r1Kyber768Draft00
+	0xFE30: GroupHybridPQ, // X25519Kyber512Draft00 (pre-standard)
+	0xFE31: GroupHybridPQ, // X25519Kyber768Draft00 (pre-standard)
+}
+
+// ClassifyGroup returns the GroupClass for a supported group / key share value
+func ClassifyGroup(group uint16) GroupClass {
+	if class, ok := pqGroups[group]; ok {
+		return class
+	}
+	if isGrease(group) {
+		return GroupUnknown
+	}
+	if _, ok := ianaSupportedGroups[group]; ok {
+		return GroupClassical
+	}
+	return GroupUnknown
+}
+
+// PQReadiness summarises the post-quantum key exchange support of a client,
+// distinguishing between groups which are merely advertised in supported_groups
+// and those for which a key share was actually sent
+type PQReadiness struct {
+	ClassicalGroups []uint16   `json:"classical_groups,omitempty"`
+	HybridGroups    []uint16   `json:"hybrid_groups,omitempty"`
+	PureGroups      []uint16   `json:"pure_groups,omitempty"`
+	HybridKeyShares []uint16   `json:"hybrid_key_shares,omitempty"`
+	PureKeyShares   []uint16   `json:"pure_key_shares,omitempty"`
+	Advertised      bool       `json:"advertised"`
+	KeyShareSent    bool       `json:"key_share_sent"`
+	Strongest       GroupClass `json:"strongest_advertised"`
+}
+
+// PQReadiness classifies the ECurves and KeyShareGroups of the fingerprint
+func (f *Fingerprint) PQReadiness() PQReadiness {
+	var p PQReadiness
+	for _, group := range f.ECurves {
+		switch ClassifyGroup(group) {
+		case GroupClassical:
+			p.ClassicalGroups = append(p.ClassicalGroups, group)
+		case GroupHybridPQ:
+			p.HybridGroups = append(p.HybridGroups, group)
+		case GroupPurePQ:
+			p.PureGroups = append(p.PureGroups, group)
+		}
+	}
+	for _, group := range f.KeyShareGroups {
+		switch ClassifyGroup(group) {
+		case GroupHybridPQ:
+			p.HybridKeyShares = append(p.HybridKeyShares, group)
+		case GroupPurePQ:
+			p.PureKeyShares = append(p.PureKeyShares, group)
+		}
+	}
+
+	p.Advertised = len(p.HybridGroups) > 0 || len(p.PureGroups) > 0
+	p.KeyShareSent = len(p.HybridKeyShares) > 0 || len(p.PureKeyShares) > 0
+
+	switch {
+	case len(p.PureGroups) > 0:
+		p.Strongest = GroupPurePQ
+	case len(p.HybridGroups) > 0:
+		p.Strongest = GroupHybridPQ
+	case len(p.ClassicalGroups) > 0:
+		p.Strongest = GroupClassical
+	}
+	return p
+}
+
+// PQCounts accumulates post-quantum capability counts over a number of
+// fingerprints, for use in summary output
+type PQCounts struct {
+	Total          int `json:"total"`
+	Advertised     int `json:"pq_advertised"`
+	Hybrid         int `json:"hybrid_advertised"`
+	Pure           int `json:"pure_advertised"`
+	KeyShareSent   int `json:"pq_key_share_sent"`
+	AdvertisedOnly int `json:"pq_advertised_only"`
+}
+
+// Add includes the fingerprint in the counts
+func (c *PQCounts) Add(f *Fingerprint) {
+	p := f.PQReadiness()
+	c.Total++
+	if p.Advertised {
+		c.Advertised++
+	}
+	if len(p.HybridGroups) > 0 {
+		c.Hybrid++
+	}
+	if len(p.PureGroups) > 0 {
+		c.Pure++
+	}
+	if p.KeyShareSent {
+		c.KeyShareSent++
+	} else if p.Advertised {
+		c.AdvertisedOnly++
+	}
+}
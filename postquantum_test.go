@@ -0,0 +1,56 @@
+package dactyloscopy_test
+
+import (
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyGroup(t *testing.T) {
+	tests := []struct {
+		group uint16
+		want  dactyloscopy.GroupClass
+	}{
+		{0x001d, dactyloscopy.GroupClassical},
+		{0x0017, dactyloscopy.GroupClassical},
+		{0x0100, dactyloscopy.GroupClassical},
+		{0x11ec, dactyloscopy.GroupHybridPQ},
+		{0x6399, dactyloscopy.GroupHybridPQ},
+		{0x0201, dactyloscopy.GroupPurePQ},
+		{0x2a2a, dactyloscopy.GroupUnknown},
+		{0x1234, dactyloscopy.GroupUnknown},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, dactyloscopy.ClassifyGroup(tt.group), "group 0x%04x", tt.group)
+	}
+}
+
+func TestPQReadiness(t *testing.T) {
+	// Chrome-ish: advertises and sends X25519MLKEM768
+	fp := &dactyloscopy.Fingerprint{
+		ECurves:        []uint16{0x2a2a, 0x11ec, 0x001d, 0x0017, 0x0018},
+		KeyShareGroups: []uint16{0x2a2a, 0x11ec, 0x001d},
+	}
+	p := fp.PQReadiness()
+	assert.True(t, p.Advertised)
+	assert.True(t, p.KeyShareSent)
+	assert.Equal(t, []uint16{0x11ec}, p.HybridGroups)
+	assert.Equal(t, []uint16{0x11ec}, p.HybridKeyShares)
+	assert.Equal(t, []uint16{0x001d, 0x0017, 0x0018}, p.ClassicalGroups)
+	assert.Equal(t, dactyloscopy.GroupHybridPQ, p.Strongest)
+
+	// Advertised only, no PQ key share sent
+	fp = &dactyloscopy.Fingerprint{
+		ECurves:        []uint16{0x001d, 0x11ec},
+		KeyShareGroups: []uint16{0x001d},
+	}
+	p = fp.PQReadiness()
+	assert.True(t, p.Advertised)
+	assert.False(t, p.KeyShareSent)
+
+	var counts dactyloscopy.PQCounts
+	counts.Add(fp)
+	counts.Add(&dactyloscopy.Fingerprint{ECurves: []uint16{0x001d}})
+	assert.Equal(t, dactyloscopy.PQCounts{Total: 2, Advertised: 1, Hybrid: 1, AdvertisedOnly: 1}, counts)
+}
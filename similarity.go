@@ -0,0 +1,220 @@
+package dactyloscopy
+
+import "sort"
+
+// SimilarityWeights controls how much each component contributes to the
+// similarity score.  The component weights are normalised, so only their
+// relative size matters.  Order is the proportion of the final score given to
+// the ordering of ciphersuites, groups and signature algorithms, rather than
+// just their presence
+type SimilarityWeights struct {
+	Ciphersuites        float64
+	Extensions          float64
+	Groups              float64
+	SignatureAlgorithms float64
+	ALPN                float64
+	Order               float64
+}
+
+// DefaultSimilarityWeights favours ciphersuites and extensions, which carry
+// the most information about the client.  Extension order is deliberately
+// ignored as several clients now randomise it per connection
+var DefaultSimilarityWeights = SimilarityWeights{
+	Ciphersuites:        0.30,
+	Extensions:          0.30,
+	Groups:              0.15,
+	SignatureAlgorithms: 0.15,
+	ALPN:                0.10,
+	Order:               0.10,
+}
+
+// Similarity returns a score between 0 (nothing in common) and 1 (identical)
+// for two fingerprints, using DefaultSimilarityWeights
+func Similarity(a, b *Fingerprint) float64 {
+	return DefaultSimilarityWeights.Similarity(a, b)
+}
+
+// Similarity returns a score between 0 and 1 for two fingerprints, using a
+// weighted Jaccard index over each component plus an order sensitivity term.
+// GREASE values, and components unknown to either fingerprint, are ignored.
+func (w SimilarityWeights) Similarity(a, b *Fingerprint) float64 {
+	aCiphers, bCiphers := withoutGrease(a.Ciphersuite), withoutGrease(b.Ciphersuite)
+	aGroups, bGroups := withoutGrease(a.ECurves), withoutGrease(b.ECurves)
+	aSigAlgs, bSigAlgs := withoutGrease(a.SigAlg), withoutGrease(b.SigAlg)
+
+	// Components which either fingerprint didn't carry (e.g. ALPN in a JA3
+	// string) are left out, rather than counted as a mismatch
+	known := func(component string) bool {
+		return a.Known(component) && b.Known(component)
+	}
+	weight := func(component string, value float64) float64 {
+		if known(component) {
+			return value
+		}
+		return 0
+	}
+	wCiphers := weight(ComponentCiphersuites, w.Ciphersuites)
+	wExtensions := weight(ComponentExtensions, w.Extensions)
+	wGroups := weight(ComponentSupportedGroups, w.Groups)
+	wSigAlgs := weight(ComponentSignatureAlgorithms, w.SignatureAlgorithms)
+	wALPN := weight(ComponentALPN, w.ALPN)
+
+	total := wCiphers + wExtensions + wGroups + wSigAlgs + wALPN
+	if total == 0 {
+		return 0
+	}
+	setScore := (wCiphers*jaccard(aCiphers, bCiphers) +
+		wExtensions*jaccard(withoutGrease(a.Extensions), withoutGrease(b.Extensions)) +
+		wGroups*jaccard(aGroups, bGroups) +
+		wSigAlgs*jaccard(aSigAlgs, bSigAlgs) +
+		wALPN*jaccard(a.ALPNProtocols, b.ALPNProtocols)) / total
+
+	var orderScores []float64
+	if known(ComponentCiphersuites) && known(ComponentCiphersuiteOrder) {
+		orderScores = append(orderScores, orderSimilarity(aCiphers, bCiphers))
+	}
+	if known(ComponentSupportedGroups) {
+		orderScores = append(orderScores, orderSimilarity(aGroups, bGroups))
+	}
+	if known(ComponentSignatureAlgorithms) {
+		orderScores = append(orderScores, orderSimilarity(aSigAlgs, bSigAlgs))
+	}
+	if len(orderScores) == 0 {
+		return setScore
+	}
+	orderScore := 0.0
+	for _, score := range orderScores {
+		orderScore += score
+	}
+	orderScore /= float64(len(orderScores))
+
+	return (1-w.Order)*setScore + w.Order*orderScore
+}
+
+// KnownFingerprint is a labelled fingerprint to compare unknown clients against
+type KnownFingerprint struct {
+	Name        string
+	Fingerprint *Fingerprint
+}
+
+// SimilarityMatch is a single result from a nearest neighbour search
+type SimilarityMatch struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// Nearest returns up to n of the known fingerprints which are most similar to
+// f, best match first.  A threshold greater than 0 excludes any match scoring
+// below it
+func (w SimilarityWeights) Nearest(f *Fingerprint, known []KnownFingerprint, n int, threshold float64) []SimilarityMatch {
+	matches := make([]SimilarityMatch, 0, len(known))
+	for _, k := range known {
+		if k.Fingerprint == nil {
+			continue
+		}
+		score := w.Similarity(f, k.Fingerprint)
+		if score < threshold {
+			continue
+		}
+		matches = append(matches, SimilarityMatch{Name: k.Name, Score: score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if n > 0 && len(matches) > n {
+		matches = matches[:n]
+	}
+	return matches
+}
+
+// Nearest performs a nearest neighbour search using DefaultSimilarityWeights
+func Nearest(f *Fingerprint, known []KnownFingerprint, n int, threshold float64) []SimilarityMatch {
+	return DefaultSimilarityWeights.Nearest(f, known, n, threshold)
+}
+
+func withoutGrease(values []uint16) []uint16 {
+	out := make([]uint16, 0, len(values))
+	for _, v := range values {
+		if !isGrease(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b|, treating two empty sets as identical
+func jaccard[T comparable](a, b []T) float64 {
+	setA := make(map[T]bool, len(a))
+	for _, v := range a {
+		setA[v] = true
+	}
+	setB := make(map[T]bool, len(b))
+	for _, v := range b {
+		setB[v] = true
+	}
+	if len(setA) == 0 && len(setB) == 0 {
+		return 1
+	}
+
+	intersection := 0
+	for v := range setA {
+		if setB[v] {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(setA)+len(setB)-intersection)
+}
+
+// orderSimilarity compares the relative order of the values common to a and
+// b, returning the length of their longest common subsequence as a proportion
+// of the number of common values.  Lists with nothing in common score 0
+func orderSimilarity[T comparable](a, b []T) float64 {
+	inB := make(map[T]bool, len(b))
+	for _, v := range b {
+		inB[v] = true
+	}
+	inA := make(map[T]bool, len(a))
+	for _, v := range a {
+		inA[v] = true
+	}
+
+	var commonA, commonB []T
+	for _, v := range a {
+		if inB[v] {
+			commonA = append(commonA, v)
+		}
+	}
+	for _, v := range b {
+		if inA[v] {
+			commonB = append(commonB, v)
+		}
+	}
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(commonA) == 0 {
+		return 0
+	}
+
+	// Classic dynamic programming LCS, these lists are small
+	prev := make([]int, len(commonB)+1)
+	curr := make([]int, len(commonB)+1)
+	for i := 1; i <= len(commonA); i++ {
+		for j := 1; j <= len(commonB); j++ {
+			switch {
+			case commonA[i-1] == commonB[j-1]:
+				curr[j] = prev[j-1] + 1
+			case prev[j] > curr[j-1]:
+				curr[j] = prev[j]
+			default:
+				curr[j] = curr[j-1]
+			}
+		}
+		prev, curr = curr, prev
+	}
+	longest := len(commonA)
+	if len(commonB) > longest {
+		longest = len(commonB)
+	}
+	return float64(prev[len(commonB)]) / float64(longest)
+}
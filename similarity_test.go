@@ -0,0 +1,138 @@
+package dactyloscopy_test
+
+import (
+	"testing"
+
+	"github.com/LeeBrotherston/dactyloscopy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	base := &dactyloscopy.Fingerprint{
+		TLSVersion:    dactyloscopy.VersionTLS12,
+		Ciphersuite:   []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+		Extensions:    []uint16{0x2a2a, 0, 10, 11, 13, 16, 43, 51},
+		ECurves:       []uint16{0x2a2a, 0x001d, 0x0017},
+		SigAlg:        []uint16{0x0403, 0x0804},
+		ALPNProtocols: []string{"h2", "http/1.1"},
+	}
+
+	tests := []struct {
+		name    string
+		other   *dactyloscopy.Fingerprint
+		wantMin float64
+		wantMax float64
+	}{
+		{
+			name: "identical apart from GREASE",
+			other: &dactyloscopy.Fingerprint{
+				TLSVersion:    dactyloscopy.VersionTLS12,
+				Ciphersuite:   []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+				Extensions:    []uint16{0xdada, 0, 10, 11, 13, 16, 43, 51},
+				ECurves:       []uint16{0xdada, 0x001d, 0x0017},
+				SigAlg:        []uint16{0x0403, 0x0804},
+				ALPNProtocols: []string{"h2", "http/1.1"},
+			},
+			wantMin: 1,
+			wantMax: 1,
+		},
+		{
+			name: "one extra extension",
+			other: &dactyloscopy.Fingerprint{
+				TLSVersion:    dactyloscopy.VersionTLS12,
+				Ciphersuite:   []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+				Extensions:    []uint16{0x2a2a, 0, 10, 11, 13, 16, 43, 51, 17613},
+				ECurves:       []uint16{0x2a2a, 0x001d, 0x0017},
+				SigAlg:        []uint16{0x0403, 0x0804},
+				ALPNProtocols: []string{"h2", "http/1.1"},
+			},
+			wantMin: 0.9,
+			wantMax: 0.9999,
+		},
+		{
+			// Only the order term is penalised
+			name: "reordered ciphersuites",
+			other: &dactyloscopy.Fingerprint{
+				TLSVersion:    dactyloscopy.VersionTLS12,
+				Ciphersuite:   []uint16{0xc02b, 0x1303, 0x1302, 0x1301},
+				Extensions:    []uint16{0x2a2a, 0, 10, 11, 13, 16, 43, 51},
+				ECurves:       []uint16{0x2a2a, 0x001d, 0x0017},
+				SigAlg:        []uint16{0x0403, 0x0804},
+				ALPNProtocols: []string{"h2", "http/1.1"},
+			},
+			wantMin: 1 - dactyloscopy.DefaultSimilarityWeights.Order,
+			wantMax: 0.9999,
+		},
+		{
+			name: "nothing in common",
+			other: &dactyloscopy.Fingerprint{
+				Ciphersuite:   []uint16{0x0005},
+				Extensions:    []uint16{0xff01},
+				ECurves:       []uint16{0x0001},
+				SigAlg:        []uint16{0x0201},
+				ALPNProtocols: []string{"ftp"},
+			},
+			wantMin: 0,
+			wantMax: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := dactyloscopy.Similarity(base, tt.other)
+			assert.GreaterOrEqual(t, score, tt.wantMin-0.0001)
+			assert.LessOrEqual(t, score, tt.wantMax+0.0001)
+			assert.InDelta(t, score, dactyloscopy.Similarity(tt.other, base), 0.0001, "should be symmetric")
+		})
+	}
+}
+
+func TestNearest(t *testing.T) {
+	known := []dactyloscopy.KnownFingerprint{
+		{Name: "Legacy", Fingerprint: &dactyloscopy.Fingerprint{
+			TLSVersion:  dactyloscopy.VersionTLS12,
+			Ciphersuite: []uint16{0x002f, 0x0035, 0x000a},
+			Extensions:  []uint16{0, 10, 11, 13},
+			ECurves:     []uint16{0x0017},
+			SigAlg:      []uint16{0x0401, 0x0201},
+		}},
+		{Name: "Modern", Fingerprint: &dactyloscopy.Fingerprint{
+			TLSVersion:    dactyloscopy.VersionTLS12,
+			Ciphersuite:   []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+			Extensions:    []uint16{0, 10, 11, 13, 16, 43, 51},
+			ECurves:       []uint16{0x001d, 0x0017},
+			SigAlg:        []uint16{0x0403, 0x0804},
+			ALPNProtocols: []string{"h2"},
+		}},
+	}
+	unknown := &dactyloscopy.Fingerprint{
+		TLSVersion:    dactyloscopy.VersionTLS12,
+		Ciphersuite:   []uint16{0x1301, 0x1302, 0x1303, 0xc02b},
+		Extensions:    []uint16{0, 10, 11, 13, 16, 43},
+		ECurves:       []uint16{0x001d, 0x0017},
+		SigAlg:        []uint16{0x0403, 0x0804},
+		ALPNProtocols: []string{"h2"},
+	}
+
+	tests := []struct {
+		name     string
+		known    []dactyloscopy.KnownFingerprint
+		n        int
+		minScore float64
+		want     []string
+	}{
+		{"best match", known, 1, 0, []string{"Modern"}},
+		{"all ranked", known, 0, 0, []string{"Modern", "Legacy"}},
+		{"below minimum score", known[:1], 0, 0.5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, match := range dactyloscopy.Nearest(unknown, tt.known, tt.n, tt.minScore) {
+				got = append(got, match.Name)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
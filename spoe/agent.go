@@ -0,0 +1,356 @@
+package spoe
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Version is the SPOP version spoken by the agent
+const Version = "2.0"
+
+// DefaultMaxFrameSize is HAProxy's default max-frame-size
+const DefaultMaxFrameSize = 16380
+
+// minFrameSize is the smallest max-frame-size SPOP allows
+const minFrameSize = 256
+
+// Status codes sent in DISCONNECT frames
+const (
+	StatusNormal             uint32 = 0
+	StatusIOError            uint32 = 1
+	StatusTimeout            uint32 = 2
+	StatusFrameTooBig        uint32 = 3
+	StatusInvalidFrame       uint32 = 4
+	StatusNoVersion          uint32 = 5
+	StatusNoMaxFrameSize     uint32 = 6
+	StatusNoCapabilities     uint32 = 7
+	StatusUnsupportedVersion uint32 = 8
+	StatusBadMaxFrameSize    uint32 = 9
+	StatusNoFragmentation    uint32 = 10
+	StatusInvalidInterlaced  uint32 = 11
+	StatusFrameIDNotFound    uint32 = 12
+	StatusResourceAllocation uint32 = 13
+	StatusUnknown            uint32 = 99
+)
+
+// Handler processes the messages of a single NOTIFY frame, returning the
+// actions to send back to HAProxy
+type Handler func(messages []Message) []Action
+
+// Agent is an SPOE agent, HAProxy connects to it and sends NOTIFY frames which
+// are passed to Handler.  Frames on a connection are handled in turn, HAProxy
+// opens more connections as it needs them.
+type Agent struct {
+	Handler Handler
+	// MaxFrameSize is the largest frame the agent will accept, the smaller of
+	// this and HAProxy's value is used.  Defaults to DefaultMaxFrameSize
+	MaxFrameSize uint32
+	// IdleTimeout closes connections which haven't sent a frame for this
+	// long, zero means never
+	IdleTimeout time.Duration
+	// ErrorLog receives errors from individual connections, defaults to the
+	// standard logger
+	ErrorLog *log.Logger
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	closed    bool
+}
+
+// errDisconnect is returned when HAProxy asks to disconnect
+var errDisconnect = errors.New("haproxy disconnected")
+
+// ErrAgentClosed is returned by Serve after Close
+var ErrAgentClosed = errors.New("spoe: agent closed")
+
+// protocolError is a problem with what HAProxy sent, which is reported back
+// in an AGENT-DISCONNECT frame
+type protocolError struct {
+	status uint32
+	msg    string
+}
+
+func (e *protocolError) Error() string {
+	return fmt.Sprintf("spop error %d: %s", e.status, e.msg)
+}
+
+// ListenAndServe listens on the TCP address and serves connections from
+// HAProxy
+func (a *Agent) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return a.Serve(l)
+}
+
+// Serve accepts connections from HAProxy until the agent is closed or the
+// listener fails permanently.  Temporary errors, such as running out of file
+// descriptors, are logged and retried with a backoff as http.Server does
+func (a *Agent) Serve(l net.Listener) error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return ErrAgentClosed
+	}
+	if a.listeners == nil {
+		a.listeners = map[net.Listener]struct{}{}
+	}
+	a.listeners[l] = struct{}{}
+	a.mu.Unlock()
+
+	defer func() {
+		a.mu.Lock()
+		delete(a.listeners, l)
+		a.mu.Unlock()
+	}()
+
+	var delay time.Duration
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			a.mu.Lock()
+			closed := a.closed
+			a.mu.Unlock()
+			if closed {
+				return ErrAgentClosed
+			}
+			if !temporary(err) {
+				return err
+			}
+			delay = min(max(2*delay, 5*time.Millisecond), time.Second)
+			a.logf("spoe: accept error: %v; retrying in %v", err, delay)
+			time.Sleep(delay)
+			continue
+		}
+		delay = 0
+		go func() {
+			if err := a.ServeConn(conn); err != nil {
+				a.logf("spoe: %s: %v", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// temporary reports whether an error from Accept is worth retrying
+func temporary(err error) bool {
+	if errors.Is(err, net.ErrClosed) {
+		return false
+	}
+	var tempErr interface{ Temporary() bool }
+	return errors.As(err, &tempErr) && tempErr.Temporary()
+}
+
+// Close stops the agent accepting new connections
+func (a *Agent) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+	var err error
+	for l := range a.listeners {
+		if cerr := l.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func (a *Agent) logf(format string, args ...any) {
+	if a.ErrorLog != nil {
+		a.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// ServeConn handles a single connection from HAProxy, closing it when done.
+// A clean disconnect by HAProxy, or a health check, returns nil
+func (a *Agent) ServeConn(conn net.Conn) error {
+	defer conn.Close() // nolint:errcheck
+
+	r := bufio.NewReader(conn)
+	maxFrameSize := a.MaxFrameSize
+	if maxFrameSize == 0 {
+		maxFrameSize = DefaultMaxFrameSize
+	}
+
+	err := a.serve(conn, r, &maxFrameSize)
+	var perr *protocolError
+	switch {
+	case err == nil, errors.Is(err, errDisconnect):
+		return nil
+	case errors.As(err, &perr):
+		_ = a.disconnect(conn, perr.status, perr.msg)
+	case errors.Is(err, ErrFrameTooBig):
+		_ = a.disconnect(conn, StatusFrameTooBig, err.Error())
+	case errors.Is(err, io.EOF):
+		// HAProxy went away without saying goodbye, which it does when idle
+		return nil
+	}
+	return err
+}
+
+func (a *Agent) serve(conn net.Conn, r io.Reader, maxFrameSize *uint32) error {
+	healthcheck, err := a.hello(conn, r, maxFrameSize)
+	if err != nil || healthcheck {
+		return err
+	}
+
+	for {
+		if a.IdleTimeout > 0 {
+			if err := conn.SetReadDeadline(time.Now().Add(a.IdleTimeout)); err != nil {
+				return err
+			}
+		}
+		frame, err := ReadFrame(r, *maxFrameSize)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return &protocolError{StatusTimeout, "idle timeout"}
+			}
+			return err
+		}
+
+		switch frame.Type {
+		case FrameNotify:
+			if frame.Flags&FlagFin == 0 {
+				return &protocolError{StatusNoFragmentation, "fragmented frames are not supported"}
+			}
+			if err := a.notify(conn, frame, *maxFrameSize); err != nil {
+				return err
+			}
+		case FrameHAProxyDisconnect:
+			if err := a.disconnect(conn, StatusNormal, ""); err != nil {
+				return err
+			}
+			return errDisconnect
+		default:
+			return &protocolError{StatusInvalidFrame, fmt.Sprintf("unexpected frame type %d", frame.Type)}
+		}
+	}
+}
+
+// hello performs the handshake, reporting whether HAProxy only wanted a
+// health check
+func (a *Agent) hello(w io.Writer, r io.Reader, maxFrameSize *uint32) (bool, error) {
+	frame, err := ReadFrame(r, *maxFrameSize)
+	if err != nil {
+		return false, err
+	}
+	if frame.Type != FrameHAProxyHello {
+		return false, &protocolError{StatusInvalidFrame, fmt.Sprintf("expected HAPROXY-HELLO, got frame type %d", frame.Type)}
+	}
+	kvs, err := ParseKVList(frame.Payload)
+	if err != nil {
+		return false, &protocolError{StatusInvalidFrame, err.Error()}
+	}
+
+	var (
+		versions                        string
+		haveVersions, haveMax, haveCaps bool
+		healthcheck                     bool
+	)
+	for _, kv := range kvs {
+		switch kv.Name {
+		case "supported-versions":
+			versions, haveVersions = kv.Value.(string)
+		case "max-frame-size":
+			size, ok := kv.Value.(uint32)
+			if !ok {
+				return false, &protocolError{StatusNoMaxFrameSize, "max-frame-size is not a uint32"}
+			}
+			haveMax = true
+			if size < minFrameSize {
+				return false, &protocolError{StatusBadMaxFrameSize, fmt.Sprintf("max-frame-size %d is too small", size)}
+			}
+			*maxFrameSize = min(*maxFrameSize, size)
+		case "capabilities":
+			// Whatever HAProxy offers, we only need it to accept pipelining,
+			// which it always does
+			_, haveCaps = kv.Value.(string)
+		case "healthcheck":
+			healthcheck, _ = kv.Value.(bool)
+		}
+	}
+	switch {
+	case !haveVersions:
+		return false, &protocolError{StatusNoVersion, "supported-versions not found"}
+	case !haveMax:
+		return false, &protocolError{StatusNoMaxFrameSize, "max-frame-size not found"}
+	case !haveCaps:
+		return false, &protocolError{StatusNoCapabilities, "capabilities not found"}
+	case !supportsVersion(versions):
+		return false, &protocolError{StatusUnsupportedVersion, fmt.Sprintf("no supported version in %q", versions)}
+	}
+
+	// Frames on a connection are handled in order, so pipelining is fine but
+	// we don't offer async or fragmentation
+	payload, err := AppendKVList(nil, []KV{
+		{"version", Version},
+		{"max-frame-size", *maxFrameSize},
+		{"capabilities", "pipelining"},
+	})
+	if err != nil {
+		return false, err
+	}
+	return healthcheck, WriteFrame(w, &Frame{Type: FrameAgentHello, Flags: FlagFin, Payload: payload})
+}
+
+func supportsVersion(versions string) bool {
+	for _, v := range strings.Split(versions, ",") {
+		major, _, _ := strings.Cut(strings.TrimSpace(v), ".")
+		if major == "2" {
+			return true
+		}
+	}
+	return false
+}
+
+// notify passes the messages to the handler and acknowledges the frame
+func (a *Agent) notify(w io.Writer, frame *Frame, maxFrameSize uint32) error {
+	messages, err := ParseMessages(frame.Payload)
+	if err != nil {
+		return &protocolError{StatusInvalidFrame, err.Error()}
+	}
+
+	var actions []Action
+	if a.Handler != nil {
+		actions = a.Handler(messages)
+	}
+	payload, err := AppendActions(nil, actions)
+	if err != nil {
+		a.logf("spoe: dropping actions for stream %d: %v", frame.StreamID, err)
+		payload = nil
+	}
+	ack := &Frame{
+		Type:     FrameAck,
+		Flags:    FlagFin,
+		StreamID: frame.StreamID,
+		FrameID:  frame.FrameID,
+		Payload:  payload,
+	}
+	// 1 type, 4 flags and up to 10 each for the ids
+	if len(payload)+25 > int(maxFrameSize) {
+		a.logf("spoe: dropping actions for stream %d: ACK would exceed max-frame-size", frame.StreamID)
+		ack.Payload = nil
+	}
+	return WriteFrame(w, ack)
+}
+
+func (a *Agent) disconnect(w io.Writer, status uint32, msg string) error {
+	payload, err := AppendKVList(nil, []KV{
+		{"status-code", status},
+		{"message", msg},
+	})
+	if err != nil {
+		return err
+	}
+	return WriteFrame(w, &Frame{Type: FrameAgentDisconnect, Flags: FlagFin, Payload: payload})
+}
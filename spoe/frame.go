@@ -0,0 +1,442 @@
+// Package spoe implements the agent side of HAProxy's Stream Processing
+// Offload Protocol (SPOP) version 2.0, as described in HAProxy's SPOE.txt.
+// HAProxy sends NOTIFY frames carrying messages built from sample fetches, and
+// the agent replies with actions which set HAProxy variables.
+package spoe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// FrameType is the type of an SPOP frame
+type FrameType uint8
+
+// SPOP frame types
+const (
+	FrameUnset             FrameType = 0
+	FrameHAProxyHello      FrameType = 1
+	FrameHAProxyDisconnect FrameType = 2
+	FrameNotify            FrameType = 3
+	FrameAgentHello        FrameType = 101
+	FrameAgentDisconnect   FrameType = 102
+	FrameAck               FrameType = 103
+)
+
+// Frame flags
+const (
+	FlagFin   uint32 = 0x00000001
+	FlagAbort uint32 = 0x00000002
+)
+
+// Frame is a single SPOP frame
+type Frame struct {
+	Type     FrameType
+	Flags    uint32
+	StreamID uint64
+	FrameID  uint64
+	Payload  []byte
+}
+
+// ErrFrameTooBig is returned by ReadFrame for a frame larger than the
+// negotiated maximum
+var ErrFrameTooBig = errors.New("frame is too big")
+
+// ReadFrame reads a frame, which must be no larger than maxSize bytes
+// (excluding the 4 byte length)
+func ReadFrame(r io.Reader, maxSize uint32) (*Frame, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	if length > maxSize {
+		return nil, fmt.Errorf("%w: %d bytes, maximum is %d", ErrFrameTooBig, length, maxSize)
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("reading frame: %w", err)
+	}
+
+	d := decoder{buf: buf}
+	f := &Frame{Type: FrameType(d.byte())}
+	f.Flags = d.uint32()
+	f.StreamID = d.varint()
+	f.FrameID = d.varint()
+	if d.err != nil {
+		return nil, fmt.Errorf("reading frame metadata: %w", d.err)
+	}
+	f.Payload = d.buf
+	return f, nil
+}
+
+// WriteFrame writes a frame, including its length
+func WriteFrame(w io.Writer, f *Frame) error {
+	buf := make([]byte, 4, 4+1+4+2*binary.MaxVarintLen64+len(f.Payload))
+	buf = append(buf, byte(f.Type))
+	buf = binary.BigEndian.AppendUint32(buf, f.Flags)
+	buf = appendVarint(buf, f.StreamID)
+	buf = appendVarint(buf, f.FrameID)
+	buf = append(buf, f.Payload...)
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-4)) // #nosec G115 -- bounded by the max frame size
+	_, err := w.Write(buf)
+	return err
+}
+
+// appendVarint encodes a value in SPOP's variable length integer format, which
+// is not the same as encoding/binary's
+func appendVarint(buf []byte, i uint64) []byte {
+	if i < 240 {
+		return append(buf, byte(i))
+	}
+	buf = append(buf, byte(i)|240)
+	i = (i - 240) >> 4
+	for i >= 128 {
+		buf = append(buf, byte(i)|128)
+		i = (i - 128) >> 7
+	}
+	return append(buf, byte(i))
+}
+
+var errTruncated = errors.New("truncated data")
+
+// decoder reads SPOP encoded values, remembering the first error so that a
+// sequence of reads only needs checking once
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+	d.buf = nil
+}
+
+func (d *decoder) byte() byte {
+	if len(d.buf) < 1 {
+		d.fail(errTruncated)
+		return 0
+	}
+	b := d.buf[0]
+	d.buf = d.buf[1:]
+	return b
+}
+
+func (d *decoder) uint32() uint32 {
+	if len(d.buf) < 4 {
+		d.fail(errTruncated)
+		return 0
+	}
+	v := binary.BigEndian.Uint32(d.buf)
+	d.buf = d.buf[4:]
+	return v
+}
+
+func (d *decoder) bytes(n uint64) []byte {
+	if uint64(len(d.buf)) < n {
+		d.fail(errTruncated)
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) varint() uint64 {
+	i := uint64(d.byte())
+	if i < 240 {
+		return i
+	}
+	for shift := 4; d.err == nil; shift += 7 {
+		if shift > 63 {
+			d.fail(errors.New("varint overflows 64 bits"))
+			return 0
+		}
+		b := uint64(d.byte())
+		i += b << shift
+		if b < 128 {
+			break
+		}
+	}
+	return i
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes(d.varint()))
+}
+
+// Typed data types, the low 4 bits of the type byte
+const (
+	typeNull   = 0
+	typeBool   = 1
+	typeInt32  = 2
+	typeUint32 = 3
+	typeInt64  = 4
+	typeUint64 = 5
+	typeIPv4   = 6
+	typeIPv6   = 7
+	typeString = 8
+	typeBinary = 9
+
+	flagTrue = 0x10
+)
+
+// value decodes typed data, giving nil, bool, int32, uint32, int64, uint64,
+// net.IP, string or []byte
+func (d *decoder) value() any {
+	t := d.byte()
+	switch t & 0x0f {
+	case typeNull:
+		return nil
+	case typeBool:
+		return t&flagTrue != 0
+	case typeInt32:
+		return int32(d.varint()) // #nosec G115 -- signed values are sent as their unsigned bits
+	case typeUint32:
+		return uint32(d.varint()) // #nosec G115 -- as above
+	case typeInt64:
+		return int64(d.varint()) // #nosec G115 -- as above
+	case typeUint64:
+		return d.varint()
+	case typeIPv4:
+		return net.IP(d.bytes(net.IPv4len))
+	case typeIPv6:
+		return net.IP(d.bytes(net.IPv6len))
+	case typeString:
+		return d.string()
+	case typeBinary:
+		return d.bytes(d.varint())
+	default:
+		d.fail(fmt.Errorf("unknown data type %d", t&0x0f))
+		return nil
+	}
+}
+
+// appendValue encodes typed data
+func appendValue(buf []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, typeNull), nil
+	case bool:
+		if v {
+			return append(buf, typeBool|flagTrue), nil
+		}
+		return append(buf, typeBool), nil
+	case int32:
+		return appendVarint(append(buf, typeInt32), uint64(v)), nil // #nosec G115 -- signed values are sent as their unsigned bits
+	case uint32:
+		return appendVarint(append(buf, typeUint32), uint64(v)), nil
+	case int:
+		return appendVarint(append(buf, typeInt64), uint64(v)), nil // #nosec G115 -- as above
+	case int64:
+		return appendVarint(append(buf, typeInt64), uint64(v)), nil // #nosec G115 -- as above
+	case uint64:
+		return appendVarint(append(buf, typeUint64), v), nil
+	case net.IP:
+		if ip4 := v.To4(); ip4 != nil {
+			return append(append(buf, typeIPv4), ip4...), nil
+		}
+		if len(v) != net.IPv6len {
+			return nil, fmt.Errorf("invalid IP address %v", v)
+		}
+		return append(append(buf, typeIPv6), v...), nil
+	case string:
+		buf = appendVarint(append(buf, typeString), uint64(len(v)))
+		return append(buf, v...), nil
+	case []byte:
+		buf = appendVarint(append(buf, typeBinary), uint64(len(v)))
+		return append(buf, v...), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// KV is a single named value, as used in HELLO and DISCONNECT frames and for
+// message arguments
+type KV struct {
+	Name  string
+	Value any
+}
+
+// appendKVs encodes a list of KVs
+func appendKVs(buf []byte, kvs []KV) ([]byte, error) {
+	var err error
+	for _, kv := range kvs {
+		buf = appendVarint(buf, uint64(len(kv.Name)))
+		buf = append(buf, kv.Name...)
+		if buf, err = appendValue(buf, kv.Value); err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", kv.Name, err)
+		}
+	}
+	return buf, nil
+}
+
+// kvs decodes n KVs, or until the end of the data if n is negative
+func (d *decoder) kvs(n int) []KV {
+	var kvs []KV
+	for i := 0; (n < 0 && len(d.buf) > 0) || i < n; i++ {
+		kv := KV{Name: d.string()}
+		kv.Value = d.value()
+		if d.err != nil {
+			return nil
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs
+}
+
+// ParseKVList decodes the payload of a HELLO or DISCONNECT frame
+func ParseKVList(payload []byte) ([]KV, error) {
+	d := decoder{buf: payload}
+	kvs := d.kvs(-1)
+	return kvs, d.err
+}
+
+// AppendKVList encodes the payload of a HELLO or DISCONNECT frame
+func AppendKVList(buf []byte, kvs []KV) ([]byte, error) {
+	return appendKVs(buf, kvs)
+}
+
+// Message is a single SPOE message from a NOTIFY frame, as configured with
+// spoe-message in HAProxy
+type Message struct {
+	Name string
+	Args []KV
+}
+
+// Arg returns the value of the named argument
+func (m Message) Arg(name string) (any, bool) {
+	for _, arg := range m.Args {
+		if arg.Name == name {
+			return arg.Value, true
+		}
+	}
+	return nil, false
+}
+
+// ParseMessages decodes the payload of a NOTIFY frame
+func ParseMessages(payload []byte) ([]Message, error) {
+	d := decoder{buf: payload}
+	var messages []Message
+	for len(d.buf) > 0 {
+		m := Message{Name: d.string()}
+		m.Args = d.kvs(int(d.byte()))
+		if d.err != nil {
+			return nil, fmt.Errorf("decoding message: %w", d.err)
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// AppendMessages encodes the payload of a NOTIFY frame
+func AppendMessages(buf []byte, messages []Message) ([]byte, error) {
+	var err error
+	for _, m := range messages {
+		if len(m.Args) > 255 {
+			return nil, fmt.Errorf("message %s has too many arguments", m.Name)
+		}
+		buf = appendVarint(buf, uint64(len(m.Name)))
+		buf = append(buf, m.Name...)
+		buf = append(buf, byte(len(m.Args)))
+		if buf, err = appendKVs(buf, m.Args); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// ActionType is the type of an action in an ACK frame
+type ActionType uint8
+
+// Action types
+const (
+	ActionSetVar   ActionType = 1
+	ActionUnsetVar ActionType = 2
+)
+
+// Scope is the scope of a variable set by an action, HAProxy's proc, sess,
+// txn, req and res
+type Scope uint8
+
+// Variable scopes
+const (
+	ScopeProcess     Scope = 0
+	ScopeSession     Scope = 1
+	ScopeTransaction Scope = 2
+	ScopeRequest     Scope = 3
+	ScopeResponse    Scope = 4
+)
+
+// Action is a single action in an ACK frame.  The variable name is prefixed by
+// HAProxy with the var-prefix of the agent
+type Action struct {
+	Type  ActionType
+	Scope Scope
+	Name  string
+	// Value is only used by ActionSetVar
+	Value any
+}
+
+// SetVar returns an action which sets a variable
+func SetVar(scope Scope, name string, value any) Action {
+	return Action{Type: ActionSetVar, Scope: scope, Name: name, Value: value}
+}
+
+// UnsetVar returns an action which unsets a variable
+func UnsetVar(scope Scope, name string) Action {
+	return Action{Type: ActionUnsetVar, Scope: scope, Name: name}
+}
+
+// AppendActions encodes the payload of an ACK frame
+func AppendActions(buf []byte, actions []Action) ([]byte, error) {
+	var err error
+	for _, a := range actions {
+		switch a.Type {
+		case ActionSetVar:
+			buf = append(buf, byte(a.Type), 3, byte(a.Scope))
+		case ActionUnsetVar:
+			buf = append(buf, byte(a.Type), 2, byte(a.Scope))
+		default:
+			return nil, fmt.Errorf("unknown action type %d", a.Type)
+		}
+		buf = appendVarint(buf, uint64(len(a.Name)))
+		buf = append(buf, a.Name...)
+		if a.Type == ActionSetVar {
+			if buf, err = appendValue(buf, a.Value); err != nil {
+				return nil, fmt.Errorf("encoding %s: %w", a.Name, err)
+			}
+		}
+	}
+	return buf, nil
+}
+
+// ParseActions decodes the payload of an ACK frame
+func ParseActions(payload []byte) ([]Action, error) {
+	d := decoder{buf: payload}
+	var actions []Action
+	for len(d.buf) > 0 {
+		a := Action{Type: ActionType(d.byte())}
+		nbArgs := d.byte()
+		switch {
+		case a.Type == ActionSetVar && nbArgs == 3:
+			a.Scope = Scope(d.byte())
+			a.Name = d.string()
+			a.Value = d.value()
+		case a.Type == ActionUnsetVar && nbArgs == 2:
+			a.Scope = Scope(d.byte())
+			a.Name = d.string()
+		default:
+			d.fail(fmt.Errorf("unknown action type %d with %d arguments", a.Type, nbArgs))
+		}
+		if d.err != nil {
+			return nil, fmt.Errorf("decoding action: %w", d.err)
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil
+}
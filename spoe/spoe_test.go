@@ -0,0 +1,300 @@
+package spoe_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LeeBrotherston/dactyloscopy/spoe"
+)
+
+// haproxy is a stand-in for HAProxy's side of an SPOP connection
+type haproxy struct {
+	t    *testing.T
+	conn net.Conn
+}
+
+func dialAgent(t *testing.T, agent *spoe.Agent) *haproxy {
+	t.Helper()
+	client, server := net.Pipe()
+	go func() {
+		_ = agent.ServeConn(server)
+	}()
+	t.Cleanup(func() { _ = client.Close() })
+	return &haproxy{t: t, conn: client}
+}
+
+func (h *haproxy) send(frame *spoe.Frame) {
+	h.t.Helper()
+	require.NoError(h.t, spoe.WriteFrame(h.conn, frame))
+}
+
+func (h *haproxy) recv() *spoe.Frame {
+	h.t.Helper()
+	frame, err := spoe.ReadFrame(h.conn, spoe.DefaultMaxFrameSize)
+	require.NoError(h.t, err)
+	return frame
+}
+
+func (h *haproxy) hello(extra ...spoe.KV) []spoe.KV {
+	h.t.Helper()
+	payload, err := spoe.AppendKVList(nil, append([]spoe.KV{
+		{Name: "supported-versions", Value: "2.0"},
+		{Name: "max-frame-size", Value: uint32(16380)},
+		{Name: "capabilities", Value: "pipelining,async"},
+		{Name: "engine-id", Value: "test"},
+	}, extra...))
+	require.NoError(h.t, err)
+	h.send(&spoe.Frame{Type: spoe.FrameHAProxyHello, Flags: spoe.FlagFin, Payload: payload})
+
+	reply := h.recv()
+	require.Equal(h.t, spoe.FrameAgentHello, reply.Type)
+	kvs, err := spoe.ParseKVList(reply.Payload)
+	require.NoError(h.t, err)
+	return kvs
+}
+
+func (h *haproxy) notify(streamID, frameID uint64, messages ...spoe.Message) []spoe.Action {
+	h.t.Helper()
+	payload, err := spoe.AppendMessages(nil, messages)
+	require.NoError(h.t, err)
+	h.send(&spoe.Frame{Type: spoe.FrameNotify, Flags: spoe.FlagFin, StreamID: streamID, FrameID: frameID, Payload: payload})
+
+	ack := h.recv()
+	require.Equal(h.t, spoe.FrameAck, ack.Type)
+	assert.Equal(h.t, streamID, ack.StreamID)
+	assert.Equal(h.t, frameID, ack.FrameID)
+	actions, err := spoe.ParseActions(ack.Payload)
+	require.NoError(h.t, err)
+	return actions
+}
+
+func kvMap(kvs []spoe.KV) map[string]any {
+	out := map[string]any{}
+	for _, kv := range kvs {
+		out[kv.Name] = kv.Value
+	}
+	return out
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	in := &spoe.Frame{Type: spoe.FrameNotify, Flags: spoe.FlagFin, StreamID: 239, FrameID: 1 << 40, Payload: []byte{1, 2, 3}}
+	require.NoError(t, spoe.WriteFrame(&buf, in))
+	out, err := spoe.ReadFrame(&buf, spoe.DefaultMaxFrameSize)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	require.NoError(t, spoe.WriteFrame(&buf, &spoe.Frame{Type: spoe.FrameNotify, Payload: make([]byte, 300)}))
+	_, err = spoe.ReadFrame(&buf, 256)
+	assert.ErrorIs(t, err, spoe.ErrFrameTooBig)
+}
+
+func TestKVRoundTrip(t *testing.T) {
+	in := []spoe.KV{
+		{Name: "null", Value: nil},
+		{Name: "true", Value: true},
+		{Name: "false", Value: false},
+		{Name: "int32", Value: int32(-5)},
+		{Name: "uint32", Value: uint32(240)},
+		{Name: "int64", Value: int64(-1 << 40)},
+		{Name: "uint64", Value: uint64(1<<64 - 1)},
+		{Name: "ipv4", Value: net.IPv4(192, 0, 2, 1).To4()},
+		{Name: "ipv6", Value: net.ParseIP("2001:db8::1")},
+		{Name: "string", Value: "hello"},
+		{Name: "binary", Value: []byte{0, 1, 2}},
+	}
+	payload, err := spoe.AppendKVList(nil, in)
+	require.NoError(t, err)
+	out, err := spoe.ParseKVList(payload)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	_, err = spoe.ParseKVList(payload[:len(payload)-1])
+	assert.Error(t, err)
+	_, err = spoe.AppendKVList(nil, []spoe.KV{{Name: "bad", Value: 1.5}})
+	assert.Error(t, err)
+}
+
+func TestAgent(t *testing.T) {
+	agent := &spoe.Agent{
+		Handler: func(messages []spoe.Message) []spoe.Action {
+			var actions []spoe.Action
+			for _, m := range messages {
+				if v, ok := m.Arg("echo"); ok {
+					actions = append(actions, spoe.SetVar(spoe.ScopeSession, m.Name, v))
+				}
+			}
+			return append(actions, spoe.UnsetVar(spoe.ScopeTransaction, "gone"))
+		},
+	}
+	h := dialAgent(t, agent)
+
+	hello := kvMap(h.hello())
+	assert.Equal(t, "2.0", hello["version"])
+	assert.Equal(t, uint32(16380), hello["max-frame-size"])
+	assert.Equal(t, "pipelining", hello["capabilities"])
+
+	actions := h.notify(7, 1,
+		spoe.Message{Name: "first", Args: []spoe.KV{{Name: "echo", Value: "one"}}},
+		spoe.Message{Name: "second", Args: []spoe.KV{{Name: "echo", Value: []byte{2}}}},
+	)
+	assert.Equal(t, []spoe.Action{
+		spoe.SetVar(spoe.ScopeSession, "first", "one"),
+		spoe.SetVar(spoe.ScopeSession, "second", []byte{2}),
+		spoe.UnsetVar(spoe.ScopeTransaction, "gone"),
+	}, actions)
+
+	// Pipelined frames are answered in order
+	assert.Len(t, h.notify(8, 2), 1)
+
+	h.send(&spoe.Frame{Type: spoe.FrameHAProxyDisconnect, Flags: spoe.FlagFin})
+	bye := h.recv()
+	require.Equal(t, spoe.FrameAgentDisconnect, bye.Type)
+	kvs, err := spoe.ParseKVList(bye.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, spoe.StatusNormal, kvMap(kvs)["status-code"])
+}
+
+func TestAgentHealthcheck(t *testing.T) {
+	h := dialAgent(t, &spoe.Agent{})
+	h.hello(spoe.KV{Name: "healthcheck", Value: true})
+
+	// The agent hangs up after a health check
+	_, err := spoe.ReadFrame(h.conn, spoe.DefaultMaxFrameSize)
+	assert.Error(t, err)
+}
+
+func TestAgentBadHello(t *testing.T) {
+	h := dialAgent(t, &spoe.Agent{})
+	payload, err := spoe.AppendKVList(nil, []spoe.KV{
+		{Name: "supported-versions", Value: "1.0"},
+		{Name: "max-frame-size", Value: uint32(16380)},
+		{Name: "capabilities", Value: ""},
+	})
+	require.NoError(t, err)
+	h.send(&spoe.Frame{Type: spoe.FrameHAProxyHello, Flags: spoe.FlagFin, Payload: payload})
+
+	bye := h.recv()
+	require.Equal(t, spoe.FrameAgentDisconnect, bye.Type)
+	kvs, err := spoe.ParseKVList(bye.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, spoe.StatusUnsupportedVersion, kvMap(kvs)["status-code"])
+}
+
+func TestAgentFragmented(t *testing.T) {
+	h := dialAgent(t, &spoe.Agent{})
+	h.hello()
+	h.send(&spoe.Frame{Type: spoe.FrameNotify, StreamID: 1, FrameID: 1})
+
+	bye := h.recv()
+	require.Equal(t, spoe.FrameAgentDisconnect, bye.Type)
+	kvs, err := spoe.ParseKVList(bye.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, spoe.StatusNoFragmentation, kvMap(kvs)["status-code"])
+}
+
+func TestAgentServe(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	agent := &spoe.Agent{Handler: func([]spoe.Message) []spoe.Action {
+		return []spoe.Action{spoe.SetVar(spoe.ScopeSession, "ok", true)}
+	}}
+	done := make(chan error, 1)
+	go func() { done <- agent.Serve(l) }()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	h := &haproxy{t: t, conn: conn}
+	h.hello()
+	assert.Equal(t, []spoe.Action{spoe.SetVar(spoe.ScopeSession, "ok", true)}, h.notify(1, 1, spoe.Message{Name: "m"}))
+	require.NoError(t, conn.Close())
+
+	require.NoError(t, agent.Close())
+	assert.ErrorIs(t, <-done, spoe.ErrAgentClosed)
+}
+
+// flakyListener fails its first few Accepts with err
+type flakyListener struct {
+	net.Listener
+	err      error
+	failures int
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.failures > 0 {
+		l.failures--
+		return nil, l.err
+	}
+	return l.Listener.Accept()
+}
+
+// lockedBuffer collects log output from the agent's goroutines
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAgentServeAcceptError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantRetry bool
+	}{
+		{"timeout", os.ErrDeadlineExceeded, true},
+		{"out of file descriptors", &net.OpError{Op: "accept", Net: "tcp", Err: os.NewSyscallError("accept4", syscall.EMFILE)}, true},
+		{"permanent", errors.New("listener broken"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer l.Close() // nolint:errcheck
+			var logged lockedBuffer
+			agent := &spoe.Agent{
+				Handler:  func([]spoe.Message) []spoe.Action { return nil },
+				ErrorLog: log.New(&logged, "", 0),
+			}
+			done := make(chan error, 1)
+			go func() { done <- agent.Serve(&flakyListener{Listener: l, err: tt.err, failures: 2}) }()
+
+			if !tt.wantRetry {
+				assert.ErrorIs(t, <-done, tt.err)
+				return
+			}
+
+			// The agent still serves HAProxy after the errors
+			conn, err := net.Dial("tcp", l.Addr().String())
+			require.NoError(t, err)
+			h := &haproxy{t: t, conn: conn}
+			h.hello()
+			require.NoError(t, conn.Close())
+
+			require.NoError(t, agent.Close())
+			assert.ErrorIs(t, <-done, spoe.ErrAgentClosed)
+			assert.Equal(t, 2, strings.Count(logged.String(), "spoe: accept error"))
+		})
+	}
+}
@@ -44,6 +44,10 @@ func (f *Fingerprint) ProcessClientHello(buf []byte) error {
 		return fmt.Errorf("error generating JA3: %w", err)
 	}
 
+	if err := f.generateJA3N(); err != nil {
+		return fmt.Errorf("error generating JA3N: %w", err)
+	}
+
 	if err := f.generateJA4(); err != nil {
 		return fmt.Errorf("error generating JA4: %w", err)
 	}
@@ -232,7 +236,45 @@ func (f *Fingerprint) generateJA3() error {
 	return nil
 }
 
+// generateJA3N generates the normalised JA3, which sorts the extensions (and
+// drops GREASE) so that clients which randomise extension order, such as
+// Chrome, produce a stable hash
+func (f *Fingerprint) generateJA3N() error {
+	unhashed := fmt.Sprintf("%d,%s,%s,%s,%s",
+		f.TLSVersion,
+		sliceToDash16(f.Ciphersuite),
+		sliceToDash16(sortNumericAsc(withoutGrease(f.Extensions))),
+		sliceToDash16(f.ECurves),
+		sliceToDash8(f.EcPointFmt))
+
+	hasher := md5.New() // #nosec G401 -- used for JA3N calculation, not for security
+	if _, err := hasher.Write([]byte(unhashed)); err != nil {
+		return fmt.Errorf("calculating hash: %w", err)
+	}
+	f.JA3N = hex.EncodeToString(hasher.Sum(nil))
+	return nil
+}
+
 func (f *Fingerprint) generateJA4() error {
+	sniPresent := false
+	for _, ext := range f.Extensions {
+		if ext == ExtServerName {
+			sniPresent = true
+			break
+		}
+	}
+	ja4, err := f.ja4(sniPresent, true)
+	if err != nil {
+		return err
+	}
+	f.JA4 = ja4
+	return nil
+}
+
+// ja4 builds the JA4 string.  When the extensions aren't known their count is
+// replaced with "??" and their hash with "?", giving a partial JA4 which can
+// still be matched on the other sections
+func (f *Fingerprint) ja4(sniPresent, extensionsKnown bool) (string, error) {
 	// JA4: t<version><sni><cipher count><ext count><alpn>,<ciphers>,<exts>,<alpn-list>
 	JA4_a := "t"
 
@@ -249,20 +291,17 @@ func (f *Fingerprint) generateJA4() error {
 		JA4_a += "??"
 	}
 
-	sniPresent := false
-	for _, ext := range f.Extensions {
-		if ext == ExtServerName {
-			sniPresent = true
-			break
-		}
-	}
 	if sniPresent {
 		JA4_a += "d"
 	} else {
 		JA4_a += "i"
 	}
 
-	JA4_a += fmt.Sprintf("%02d%02d", len(f.Ciphersuite), len(f.Extensions))
+	extCount := "??"
+	if extensionsKnown {
+		extCount = fmt.Sprintf("%02d", len(f.Extensions))
+	}
+	JA4_a += fmt.Sprintf("%02d%s", len(f.Ciphersuite), extCount)
 
 	alpn := "-"
 	if len(f.ALPNProtocols) > 0 {
@@ -292,11 +331,14 @@ func (f *Fingerprint) generateJA4() error {
 
 	ciphersHash, err := hashSHA256(ciphers)
 	if err != nil {
-		return err
+		return "", err
 	}
-	extensionsHash, err := hashSHA256(extensions)
-	if err != nil {
-		return err
+	extensionsHash := "?"
+	if extensionsKnown {
+		extensionsHash, err = hashSHA256(extensions)
+		if err != nil {
+			return "", err
+		}
 	}
 	alpnHash := "-"
 	if alpnList != "-" && alpnList != "" {
@@ -304,13 +346,10 @@ func (f *Fingerprint) generateJA4() error {
 	}
 
 	// Final JA4 string: base + ,ciphers_sha256,extensions_sha256,alpn_sha256
-	JA4 = fmt.Sprintf("%s,%s,%s,%s", JA4, ciphersHash, extensionsHash, alpnHash)
-
-	f.JA4 = JA4
-	return nil
+	return fmt.Sprintf("%s,%s,%s,%s", JA4, ciphersHash, extensionsHash, alpnHash), nil
 }
 
-// MakeHashes generates both JA3 and LB1 hashes from the fingerprint data
+// MakeHashes generates the JA3, JA3N and JA4 hashes from the fingerprint data
 // If this method isn't needed, it should be removed since generateHashes()
 // is already handling the JA3 hash generation
 func (f *Fingerprint) MakeHashes() error {
@@ -318,6 +357,12 @@ func (f *Fingerprint) MakeHashes() error {
 	if err := f.generateJA3(); err != nil {
 		return fmt.Errorf("generating JA3 hash: %w", err)
 	}
+	if err := f.generateJA3N(); err != nil {
+		return fmt.Errorf("generating JA3N hash: %w", err)
+	}
+	if err := f.generateJA4(); err != nil {
+		return fmt.Errorf("generating JA4 hash: %w", err)
+	}
 
 	// Generate LB1 hash if needed
 	// TODO: Implement LB1 hash generation if required
@@ -2,6 +2,7 @@ package dactyloscopy
 
 import (
 	"fmt"
+	"slices"
 
 	"golang.org/x/crypto/cryptobyte"
 )
@@ -27,16 +28,49 @@ type Fingerprint struct {
 	Cookie              string   `json:"cookie,omitempty"`
 	RenegotiationInfo   string   `json:"renegotiation_info,omitempty"`
 	SessionTicketLen    int      `json:"session_ticket_len,omitempty"`
+	Padding             bool     `json:"padding,omitempty"`
+	PaddingLen          int      `json:"padding_len,omitempty"`
 
 	//LB1               string   `json:"lb1,omitempty"`
-	JA3 string `json:"ja3,omitempty"`
-	JA4 string `json:"ja4,omitempty"`
-	SNI string `json:"sni,omitempty"`
+	JA3  string `json:"ja3,omitempty"`
+	JA3N string `json:"ja3n,omitempty"`
+	JA4  string `json:"ja4,omitempty"`
+	SNI  string `json:"sni,omitempty"`
+
+	// Unknown lists the components which the source of a partial fingerprint
+	// (e.g. a JA3 string) didn't carry, so their values above are empty or
+	// only a hint.  It is empty for fingerprints parsed from a ClientHello
+	Unknown []string `json:"unknown,omitempty"`
 
 	rawSuites     cryptobyte.String
 	rawExtensions cryptobyte.String
 }
 
+// Component names, as used by Diff and in Fingerprint.Unknown
+const (
+	ComponentTLSVersion          = "tls_version"
+	ComponentCiphersuites        = "ciphersuites"
+	ComponentExtensions          = "extensions"
+	ComponentSupportedGroups     = "supported_groups"
+	ComponentECPointFormats      = "ec_point_formats"
+	ComponentSignatureAlgorithms = "signature_algorithms"
+	ComponentSupportedVersions   = "supported_versions"
+	ComponentALPN                = "alpn"
+	ComponentKeyShares           = "key_shares"
+	ComponentCompression         = "compression"
+	ComponentSNI                 = "sni"
+	// The order of the ciphersuites or extensions can be unknown even when
+	// their values are known, as JA4 sorts them
+	ComponentCiphersuiteOrder = "ciphersuite_order"
+	ComponentExtensionOrder   = "extension_order"
+)
+
+// Known reports whether a component was present in the source of the
+// fingerprint, see Unknown
+func (f *Fingerprint) Known(component string) bool {
+	return !slices.Contains(f.Unknown, component)
+}
+
 // Validate checks if the fingerprint data is valid
 func (f *Fingerprint) Validate() error {
 	// Check required fields